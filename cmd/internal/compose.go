@@ -0,0 +1,436 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util/blob"
+)
+
+// Config composition kinds. These documents are expanded by the ConfigParser
+// and never reach server.UnmarshalResourceConfig.
+const (
+	includeKind       = "include"
+	parameterSetKind  = "parameterSet"
+	toolTemplateKind  = "toolTemplate"
+	promptPartialKind = "promptPartial"
+)
+
+// loadDocs parses a config file into v2 documents, replacing `include`
+// documents with the documents of the included files. location is the path
+// or URL of the file, used to resolve relative includes. stack holds the
+// locations of the including files to detect include cycles.
+func (p *ConfigParser) loadDocs(ctx context.Context, raw []byte, location string, stack []string) ([]yaml.MapSlice, error) {
+	// Replace environment variables if found
+	output, err := p.parseEnv(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing environment variables: %s", err)
+	}
+
+	converted, err := ConvertConfig([]byte(output))
+	if err != nil {
+		return nil, fmt.Errorf("error converting config file: %s", err)
+	}
+
+	var docs []yaml.MapSlice
+	decoder := yaml.NewDecoder(bytes.NewReader(converted), yaml.UseOrderedMap())
+	for {
+		var doc yaml.MapSlice
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if kind, _ := lookup(doc, "kind"); kind != includeKind {
+			docs = append(docs, doc)
+			continue
+		}
+
+		files, err := includedFiles(doc)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			target, err := resolveInclude(location, f)
+			if err != nil {
+				return nil, err
+			}
+			if target == location || slices.Contains(stack, target) {
+				return nil, fmt.Errorf("include cycle detected: %q is already being included", target)
+			}
+			// the file is recorded before it is read, so that a missing or
+			// invalid include is reloaded once it is fixed
+			if !slices.Contains(p.includes, target) {
+				p.includes = append(p.includes, target)
+			}
+			buf, err := readConfigFile(ctx, target)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read included config file at %q: %w", target, err)
+			}
+			included, err := p.loadDocs(ctx, buf, target, append(stack, location))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse included config file at %q: %w", target, err)
+			}
+			docs = append(docs, included...)
+		}
+	}
+	return docs, nil
+}
+
+// includedFiles returns the files listed by an `include` document.
+func includedFiles(doc yaml.MapSlice) ([]string, error) {
+	var files []string
+	for _, item := range doc {
+		switch item.Key {
+		case "kind":
+		case "files":
+			list, ok := item.Value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("include: files must be a list of paths")
+			}
+			for _, f := range list {
+				s, ok := f.(string)
+				if !ok || s == "" {
+					return nil, fmt.Errorf("include: files must be a list of paths")
+				}
+				files = append(files, s)
+			}
+		default:
+			return nil, fmt.Errorf("include: unknown field %q", item.Key)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("include: files must not be empty")
+	}
+	return files, nil
+}
+
+// resolveInclude resolves an included file relative to the including file.
+// The files included by a remote config are resolved against its URL, and
+// must be remote as well, so that a remote config can't read local files.
+func resolveInclude(base, file string) (string, error) {
+	if blob.IsRemote(file) {
+		return file, nil
+	}
+	if blob.IsRemote(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", fmt.Errorf("invalid config location %q: %w", base, err)
+		}
+		ref, err := url.Parse(filepath.ToSlash(file))
+		if err != nil {
+			return "", fmt.Errorf("invalid included file %q: %w", file, err)
+		}
+		resolved := baseURL.ResolveReference(ref).String()
+		if !blob.IsRemote(resolved) {
+			return "", fmt.Errorf("included file %q of remote config %q must be a remote location", file, base)
+		}
+		return resolved, nil
+	}
+	if base == "" || filepath.IsAbs(file) {
+		return file, nil
+	}
+	return filepath.Join(filepath.Dir(base), file), nil
+}
+
+// composer expands parameter sets, tool templates and prompt partials. The
+// definitions are collected from every loaded config file (and the files they
+// include), so that any file can reference them.
+type composer struct {
+	parameterSets  map[string][]any
+	toolTemplates  map[string]yaml.MapSlice
+	promptPartials yaml.MapSlice
+}
+
+func newComposer() *composer {
+	return &composer{
+		parameterSets: make(map[string][]any),
+		toolTemplates: make(map[string]yaml.MapSlice),
+	}
+}
+
+// expandDocs expands all parameter set references and tool templates, adds
+// the prompt partials to the prompts, and returns the remaining documents
+// encoded as a v2 config file.
+func expandDocs(docs []yaml.MapSlice) ([]byte, error) {
+	c := newComposer()
+	resources, err := c.collect(docs)
+	if err != nil {
+		return nil, err
+	}
+	return c.expand(resources)
+}
+
+// collect adds the parameter sets, tool templates and prompt partials among
+// docs to the composer, and returns the remaining resource documents.
+func (c *composer) collect(docs []yaml.MapSlice) ([]yaml.MapSlice, error) {
+	var resources []yaml.MapSlice
+	for _, doc := range docs {
+		kind, _ := lookup(doc, "kind")
+		switch kind {
+		case parameterSetKind:
+			if err := c.addParameterSet(doc); err != nil {
+				return nil, err
+			}
+		case toolTemplateKind:
+			name, ok := lookup(doc, "name")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("toolTemplate: name is required")
+			}
+			if _, exists := c.toolTemplates[name]; exists {
+				return nil, fmt.Errorf("toolTemplate %q is defined more than once", name)
+			}
+			c.toolTemplates[name] = doc
+		case promptPartialKind:
+			if err := c.addPromptPartial(doc); err != nil {
+				return nil, err
+			}
+		default:
+			resources = append(resources, doc)
+		}
+	}
+	return resources, nil
+}
+
+// expand expands the tools and prompts among resources and returns them
+// encoded as a v2 config file.
+func (c *composer) expand(resources []yaml.MapSlice) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	for _, doc := range resources {
+		switch kind, _ := lookup(doc, "kind"); kind {
+		case "tool":
+			name, _ := lookup(doc, "name")
+			var err error
+			doc, err = c.expandTool(doc)
+			if err != nil {
+				return nil, fmt.Errorf("unable to expand tool %q: %w", name, err)
+			}
+		case "prompt":
+			name, _ := lookup(doc, "name")
+			var err error
+			doc, err = c.expandPrompt(doc)
+			if err != nil {
+				return nil, fmt.Errorf("unable to expand prompt %q: %w", name, err)
+			}
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *composer) addParameterSet(doc yaml.MapSlice) error {
+	var name string
+	var params []any
+	for _, item := range doc {
+		switch item.Key {
+		case "kind":
+		case "name":
+			name, _ = item.Value.(string)
+		case "parameters":
+			list, ok := item.Value.([]any)
+			if !ok {
+				return fmt.Errorf("parameterSet: parameters must be a list")
+			}
+			params = list
+		default:
+			return fmt.Errorf("parameterSet: unknown field %q", item.Key)
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("parameterSet: name is required")
+	}
+	if _, exists := c.parameterSets[name]; exists {
+		return fmt.Errorf("parameterSet %q is defined more than once", name)
+	}
+	c.parameterSets[name] = params
+	return nil
+}
+
+func (c *composer) addPromptPartial(doc yaml.MapSlice) error {
+	var name, text string
+	var ok bool
+	for _, item := range doc {
+		switch item.Key {
+		case "kind":
+		case "name":
+			name, _ = item.Value.(string)
+		case "template":
+			if text, ok = item.Value.(string); !ok {
+				return fmt.Errorf("promptPartial: template must be a string")
+			}
+		default:
+			return fmt.Errorf("promptPartial: unknown field %q", item.Key)
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("promptPartial: name is required")
+	}
+	for _, p := range c.promptPartials {
+		if p.Key == name {
+			return fmt.Errorf("promptPartial %q is defined more than once", name)
+		}
+	}
+	c.promptPartials = append(c.promptPartials, yaml.MapItem{Key: name, Value: text})
+	return nil
+}
+
+// expandPrompt adds the prompt partials to the partials of the prompt. The
+// prompt's own partials take precedence.
+func (c *composer) expandPrompt(doc yaml.MapSlice) (yaml.MapSlice, error) {
+	if len(c.promptPartials) == 0 {
+		return doc, nil
+	}
+	partials := slices.Clone(c.promptPartials)
+	for _, item := range doc {
+		if item.Key != "partials" {
+			continue
+		}
+		own, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, fmt.Errorf("partials must be a map of templates")
+		}
+		for _, p := range own {
+			partials = set(partials, p)
+		}
+	}
+	return set(doc, yaml.MapItem{Key: "partials", Value: partials}), nil
+}
+
+// expandTool applies the tool's template (if any) and expands parameter set
+// references in its parameters.
+func (c *composer) expandTool(doc yaml.MapSlice) (yaml.MapSlice, error) {
+	doc, err := c.applyTemplate(doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range doc {
+		if item.Key != "parameters" {
+			continue
+		}
+		list, ok := item.Value.([]any)
+		if !ok {
+			break
+		}
+		expanded, err := c.expandParameters(list, nil)
+		if err != nil {
+			return nil, err
+		}
+		doc[i].Value = expanded
+	}
+	return doc, nil
+}
+
+// applyTemplate merges the fields of the template referenced by doc (and
+// the templates it references in turn) into doc. Fields set on doc take
+// precedence over the template's. chain holds the templates being applied
+// to detect cycles.
+func (c *composer) applyTemplate(doc yaml.MapSlice, chain []string) (yaml.MapSlice, error) {
+	name, ok := lookup(doc, "template")
+	if !ok {
+		return doc, nil
+	}
+	if slices.Contains(chain, name) {
+		return nil, fmt.Errorf("toolTemplate cycle detected: %s", strings.Join(append(chain, name), " -> "))
+	}
+	tmpl, ok := c.toolTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("toolTemplate %q not found", name)
+	}
+	base, err := c.applyTemplate(tmpl, append(chain, name))
+	if err != nil {
+		return nil, err
+	}
+
+	merged := yaml.MapSlice{}
+	for _, key := range []string{"kind", "name"} {
+		for _, item := range doc {
+			if item.Key == key {
+				merged = append(merged, item)
+			}
+		}
+	}
+	for _, src := range []yaml.MapSlice{base, doc} {
+		for _, item := range src {
+			switch item.Key {
+			case "kind", "name", "template":
+				continue
+			}
+			merged = set(merged, item)
+		}
+	}
+	return merged, nil
+}
+
+// expandParameters replaces `- parameterSet: <name>` items with the
+// parameters of the named set.
+func (c *composer) expandParameters(list []any, chain []string) ([]any, error) {
+	var expanded []any
+	for _, item := range list {
+		ref, ok := item.(yaml.MapSlice)
+		if !ok || len(ref) != 1 || ref[0].Key != parameterSetKind {
+			expanded = append(expanded, item)
+			continue
+		}
+		name, _ := ref[0].Value.(string)
+		if slices.Contains(chain, name) {
+			return nil, fmt.Errorf("parameterSet cycle detected: %s", strings.Join(append(chain, name), " -> "))
+		}
+		params, ok := c.parameterSets[name]
+		if !ok {
+			return nil, fmt.Errorf("parameterSet %q not found", name)
+		}
+		params, err := c.expandParameters(params, append(chain, name))
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, params...)
+	}
+	return expanded, nil
+}
+
+// lookup returns the string value of key in doc.
+func lookup(doc yaml.MapSlice, key string) (string, bool) {
+	for _, item := range doc {
+		if item.Key == key {
+			s, ok := item.Value.(string)
+			return s, ok
+		}
+	}
+	return "", false
+}
+
+// set replaces the value of item.Key in doc, or appends item if not present.
+func set(doc yaml.MapSlice, item yaml.MapItem) yaml.MapSlice {
+	for i := range doc {
+		if doc[i].Key == item.Key {
+			doc[i].Value = item.Value
+			return doc
+		}
+	}
+	return append(doc, item)
+}
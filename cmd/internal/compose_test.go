@@ -0,0 +1,426 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestParseConfigComposition(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tenantParam := parameters.NewStringParameterWithAuth("tenant_id", "the tenant id", []parameters.ParamAuthService{{Name: "my-google-auth", Field: "hd"}})
+	limitParam := parameters.NewIntParameterWithDefault("limit", 10, "max rows")
+
+	tcs := []struct {
+		description string
+		in          string
+		want        server.ToolConfigs
+	}{
+		{
+			description: "parameter sets and templates v2",
+			in: `
+			kind: parameterSet
+			name: tenant
+			parameters:
+				- name: tenant_id
+					type: string
+					description: the tenant id
+					authServices:
+						- name: my-google-auth
+							field: hd
+---
+			kind: parameterSet
+			name: pagination
+			parameters:
+				- name: limit
+					type: integer
+					default: 10
+					description: max rows
+---
+			kind: toolTemplate
+			name: tenant-query
+			type: postgres-sql
+			source: my-pg-instance
+			description: template description
+			authRequired:
+				- my-google-auth
+			parameters:
+				- parameterSet: tenant
+---
+			kind: tool
+			name: list_orders
+			template: tenant-query
+			description: list orders
+			statement: SELECT * FROM orders;
+			parameters:
+				- parameterSet: tenant
+				- parameterSet: pagination
+---
+			kind: tool
+			name: list_users
+			template: tenant-query
+			statement: SELECT * FROM users;
+			`,
+			want: server.ToolConfigs{
+				"list_orders": postgressql.Config{
+					Name:         "list_orders",
+					Type:         "postgres-sql",
+					Source:       "my-pg-instance",
+					Description:  "list orders",
+					Statement:    "SELECT * FROM orders;",
+					AuthRequired: []string{"my-google-auth"},
+					Parameters:   parameters.Parameters{tenantParam, limitParam},
+				},
+				"list_users": postgressql.Config{
+					Name:         "list_users",
+					Type:         "postgres-sql",
+					Source:       "my-pg-instance",
+					Description:  "template description",
+					Statement:    "SELECT * FROM users;",
+					AuthRequired: []string{"my-google-auth"},
+					Parameters:   parameters.Parameters{tenantParam},
+				},
+			},
+		},
+		{
+			description: "chained templates v1",
+			in: `
+			parameterSets:
+				pagination:
+					parameters:
+						- name: limit
+							type: integer
+							default: 10
+							description: max rows
+			toolTemplates:
+				base:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: base description
+				paginated:
+					template: base
+					parameters:
+						- parameterSet: pagination
+			tools:
+				list_orders:
+					template: paginated
+					source: other-pg-instance
+					statement: SELECT * FROM orders;
+			`,
+			want: server.ToolConfigs{
+				"list_orders": postgressql.Config{
+					Name:         "list_orders",
+					Type:         "postgres-sql",
+					Source:       "other-pg-instance",
+					Description:  "base description",
+					Statement:    "SELECT * FROM orders;",
+					AuthRequired: []string{},
+					Parameters:   parameters.Parameters{limitParam},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			parser := ConfigParser{}
+			got, err := parser.ParseConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect tools parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseConfigPromptPartials(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	in := `
+			kind: promptPartial
+			name: style
+			template: Answer in {{.language}}.
+---
+			kind: promptPartial
+			name: footer
+			template: Thanks!
+---
+			kind: prompt
+			name: summarize
+			partials:
+				footer: Bye!
+			messages:
+				- content: Summarize this. {{template "style" .}} {{template "footer" .}}
+			arguments:
+				- name: language
+					description: the language of the answer
+			`
+	want := server.PromptConfigs{
+		"summarize": &custom.Config{
+			Name: "summarize",
+			Messages: []prompts.Message{
+				{Role: "user", Content: `Summarize this. {{template "style" .}} {{template "footer" .}}`},
+			},
+			Arguments: prompts.Arguments{
+				{Parameter: parameters.NewStringParameter("language", "the language of the answer")},
+			},
+			Partials: map[string]string{"style": "Answer in {{.language}}.", "footer": "Bye!"},
+		},
+	}
+
+	parser := ConfigParser{}
+	got, err := parser.ParseConfig(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("failed to parse input: %v", err)
+	}
+	if diff := cmp.Diff(want, got.Prompts); diff != "" {
+		t.Fatalf("incorrect prompts parse: diff %v", diff)
+	}
+}
+
+func TestParseConfigCompositionErrors(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		description string
+		in          string
+		err         string
+	}{
+		{
+			description: "unknown template",
+			in: `
+			kind: tool
+			name: my_tool
+			template: missing
+			`,
+			err: `toolTemplate "missing" not found`,
+		},
+		{
+			description: "template cycle",
+			in: `
+			kind: toolTemplate
+			name: a
+			template: b
+---
+			kind: toolTemplate
+			name: b
+			template: a
+---
+			kind: tool
+			name: my_tool
+			template: a
+			`,
+			err: "toolTemplate cycle detected: a -> b -> a",
+		},
+		{
+			description: "unknown parameter set",
+			in: `
+			kind: tool
+			name: my_tool
+			type: postgres-sql
+			source: my-pg-instance
+			description: d
+			statement: SELECT 1;
+			parameters:
+				- parameterSet: missing
+			`,
+			err: `parameterSet "missing" not found`,
+		},
+		{
+			description: "duplicate parameter set",
+			in: `
+			kind: parameterSet
+			name: a
+			parameters: []
+---
+			kind: parameterSet
+			name: a
+			parameters: []
+			`,
+			err: `parameterSet "a" is defined more than once`,
+		},
+		{
+			description: "duplicate prompt partial",
+			in: `
+			kind: promptPartial
+			name: a
+			template: one
+---
+			kind: promptPartial
+			name: a
+			template: two
+			`,
+			err: `promptPartial "a" is defined more than once`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			parser := ConfigParser{}
+			_, err := parser.ParseConfig(ctx, testutils.FormatYaml(tc.in))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigsWithInclude(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"tools.yaml": "kind: include\nfiles:\n  - common/params.yaml\n---\n" +
+			"kind: tool\nname: my_tool\ntype: postgres-sql\nsource: my-pg-instance\ndescription: d\nstatement: SELECT 1;\nparameters:\n  - parameterSet: pagination\n",
+		"common/params.yaml": "kind: include\nfiles:\n  - toolsets.yaml\n---\n" +
+			"kind: parameterSet\nname: pagination\nparameters:\n  - name: limit\n    type: integer\n    description: max rows\n",
+		"common/toolsets.yaml": "kind: toolset\nname: my_toolset\ntools:\n  - my_tool\n",
+		"cycle.yaml":           "kind: include\nfiles:\n  - cycle.yaml\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("unable to create dir: %s", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write file: %s", err)
+		}
+	}
+
+	parser := ConfigParser{}
+	got, err := parser.LoadAndMergeConfigs(ctx, []string{filepath.Join(dir, "tools.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tool, ok := got.Tools["my_tool"].(postgressql.Config)
+	if !ok {
+		t.Fatalf("expected my_tool to be loaded, got %v", got.Tools)
+	}
+	if diff := cmp.Diff(parameters.Parameters{parameters.NewIntParameter("limit", "max rows")}, tool.Parameters); diff != "" {
+		t.Fatalf("incorrect parameters: diff %v", diff)
+	}
+	if _, ok := got.Toolsets["my_toolset"]; !ok {
+		t.Fatalf("expected toolset from nested include to be loaded")
+	}
+	wantIncludes := []string{filepath.Join(dir, "common/params.yaml"), filepath.Join(dir, "common/toolsets.yaml")}
+	if diff := cmp.Diff(wantIncludes, parser.Includes()); diff != "" {
+		t.Fatalf("incorrect includes: diff %v", diff)
+	}
+
+	_, err = parser.LoadAndMergeConfigs(ctx, []string{filepath.Join(dir, "cycle.yaml")})
+	if err == nil || !strings.Contains(err.Error(), "include cycle detected") {
+		t.Fatalf("expected include cycle error, got %v", err)
+	}
+}
+
+func TestLoadConfigsAcrossFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"tools.yaml": "kind: tool\nname: my_tool\ntemplate: pg-query\ndescription: d\nstatement: SELECT 1;\nparameters:\n  - parameterSet: pagination\n",
+		"common.yaml": "kind: parameterSet\nname: pagination\nparameters:\n  - name: limit\n    type: integer\n    description: max rows\n---\n" +
+			"kind: toolTemplate\nname: pg-query\ntype: postgres-sql\nsource: my-pg-instance\n",
+		"dup.yaml": "kind: parameterSet\nname: pagination\nparameters: []\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write file: %s", err)
+		}
+	}
+
+	parser := ConfigParser{}
+	got, err := parser.LoadAndMergeConfigs(ctx, []string{filepath.Join(dir, "tools.yaml"), filepath.Join(dir, "common.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tool, ok := got.Tools["my_tool"].(postgressql.Config)
+	if !ok {
+		t.Fatalf("expected my_tool to be a postgres-sql tool, got %v", got.Tools)
+	}
+	if tool.Source != "my-pg-instance" {
+		t.Fatalf("expected source from template, got %q", tool.Source)
+	}
+	if diff := cmp.Diff(parameters.Parameters{parameters.NewIntParameter("limit", "max rows")}, tool.Parameters); diff != "" {
+		t.Fatalf("incorrect parameters: diff %v", diff)
+	}
+
+	_, err = parser.LoadAndMergeConfigs(ctx, []string{filepath.Join(dir, "common.yaml"), filepath.Join(dir, "dup.yaml")})
+	if err == nil || !strings.Contains(err.Error(), `parameterSet "pagination" is defined more than once`) {
+		t.Fatalf("expected duplicate parameterSet error, got %v", err)
+	}
+}
+
+func TestResolveInclude(t *testing.T) {
+	tcs := []struct {
+		desc string
+		base string
+		file string
+		want string
+		err  string
+	}{
+		{desc: "local relative", base: "/configs/tools.yaml", file: "common/params.yaml", want: "/configs/common/params.yaml"},
+		{desc: "local absolute", base: "/configs/tools.yaml", file: "/etc/params.yaml", want: "/etc/params.yaml"},
+		{desc: "local to remote", base: "/configs/tools.yaml", file: "https://example.com/params.yaml", want: "https://example.com/params.yaml"},
+		{desc: "remote relative", base: "https://example.com/configs/tools.yaml", file: "../params.yaml", want: "https://example.com/params.yaml"},
+		{desc: "remote absolute path", base: "https://example.com/configs/tools.yaml", file: "/etc/passwd", want: "https://example.com/etc/passwd"},
+		{desc: "remote to remote", base: "https://example.com/tools.yaml", file: "http://other.example.com/params.yaml", want: "http://other.example.com/params.yaml"},
+		{desc: "remote to file url", base: "https://example.com/tools.yaml", file: "file:///etc/passwd", err: "must be a remote location"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := resolveInclude(tc.base, tc.file)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -27,8 +27,9 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
-	"github.com/googleapis/genai-toolbox/internal/auth/generic"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/util/blob"
 )
 
 type Config struct {
@@ -42,6 +43,16 @@ type Config struct {
 
 type ConfigParser struct {
 	EnvVars map[string]string
+	// includes holds the locations of the files included by the last loaded
+	// config files.
+	includes []string
+}
+
+// Includes returns the resolved locations of the files included by the
+// config files last loaded with LoadAndMergeConfigs, so that they can be
+// watched for changes along with them.
+func (p *ConfigParser) Includes() []string {
+	return slices.Clone(p.includes)
 }
 
 // parseEnv replaces environment variables ${ENV_NAME} with their values.
@@ -77,19 +88,23 @@ func (p *ConfigParser) parseEnv(input string) (string, error) {
 // ParseConfig parses the provided yaml into appropriate configs.
 func (p *ConfigParser) ParseConfig(ctx context.Context, raw []byte) (Config, error) {
 	var config Config
-	// Replace environment variables if found
-	output, err := p.parseEnv(string(raw))
+	docs, err := p.loadDocs(ctx, raw, "", nil)
 	if err != nil {
-		return config, fmt.Errorf("error parsing environment variables: %s", err)
+		return config, err
 	}
-	raw = []byte(output)
 
-	raw, err = ConvertConfig(raw)
+	// Expand parameter sets and tool templates
+	raw, err = expandDocs(docs)
 	if err != nil {
-		return config, fmt.Errorf("error converting config file: %s", err)
+		return config, fmt.Errorf("error expanding config file: %s", err)
 	}
+	return unmarshalConfig(ctx, raw)
+}
 
-	// Parse contents
+// unmarshalConfig parses an expanded v2 config file into appropriate configs.
+func unmarshalConfig(ctx context.Context, raw []byte) (Config, error) {
+	var config Config
+	var err error
 	config.Sources, config.AuthServices, config.EmbeddingModels, config.Tools, config.Toolsets, config.Prompts, err = server.UnmarshalResourceConfig(ctx, raw)
 	if err != nil {
 		return config, err
@@ -106,7 +121,7 @@ func ConvertConfig(raw []byte) ([]byte, error) {
 	var buf bytes.Buffer
 	encoder := yaml.NewEncoder(&buf)
 
-	v1keys := []string{"sources", "authServices", "embeddingModels", "tools", "toolsets", "prompts"}
+	v1keys := []string{"sources", "authServices", "embeddingModels", "tools", "toolsets", "prompts", "parameterSets", "toolTemplates", "promptPartials"}
 	for {
 		if err := decoder.Decode(&input); err != nil {
 			if err == io.EOF {
@@ -139,6 +154,12 @@ func ConvertConfig(raw []byte) ([]byte, error) {
 						key = "toolset"
 					case "prompts":
 						key = "prompt"
+					case "parameterSets":
+						key = "parameterSet"
+					case "toolTemplates":
+						key = "toolTemplate"
+					case "promptPartials":
+						key = "promptPartial"
 					}
 					transformed, err := transformDocs(key, slice)
 					if err != nil {
@@ -313,8 +334,7 @@ func mergeConfigs(files ...Config) (Config, error) {
 	// Ensure only one authService has mcpEnabled = true
 	var mcpEnabledAuthServers []string
 	for name, authService := range merged.AuthServices {
-		// Only generic type has McpEnabled right now
-		if genericService, ok := authService.(generic.Config); ok && genericService.McpEnabled {
+		if mcpService, ok := authService.(auth.MCPAuthServiceConfig); ok && mcpService.IsMcpEnabled() {
 			mcpEnabledAuthServers = append(mcpEnabledAuthServers, name)
 		}
 	}
@@ -325,17 +345,37 @@ func mergeConfigs(files ...Config) (Config, error) {
 	return merged, nil
 }
 
-// LoadAndMergeConfigs loads multiple YAML files and merges them
+// LoadAndMergeConfigs loads multiple YAML files and merges them. Parameter
+// sets, tool templates and prompt partials are collected from all files
+// before any tool or prompt is expanded, so they can be used across files.
 func (p *ConfigParser) LoadAndMergeConfigs(ctx context.Context, filePaths []string) (Config, error) {
-	var configs []Config
-
-	for _, filePath := range filePaths {
-		buf, err := os.ReadFile(filePath)
+	p.includes = nil
+	c := newComposer()
+	resources := make([][]yaml.MapSlice, len(filePaths))
+	for i, filePath := range filePaths {
+		buf, err := readConfigFile(ctx, filePath)
 		if err != nil {
 			return Config{}, fmt.Errorf("unable to read config file at %q: %w", filePath, err)
 		}
 
-		config, err := p.ParseConfig(ctx, buf)
+		// Includes are resolved relative to the file
+		docs, err := p.loadDocs(ctx, buf, filePath, nil)
+		if err != nil {
+			return Config{}, fmt.Errorf("unable to parse config file at %q: %w", filePath, err)
+		}
+		resources[i], err = c.collect(docs)
+		if err != nil {
+			return Config{}, fmt.Errorf("unable to parse config file at %q: %w", filePath, err)
+		}
+	}
+
+	var configs []Config
+	for i, filePath := range filePaths {
+		raw, err := c.expand(resources[i])
+		if err != nil {
+			return Config{}, fmt.Errorf("unable to parse config file at %q: error expanding config file: %w", filePath, err)
+		}
+		config, err := unmarshalConfig(ctx, raw)
 		if err != nil {
 			return Config{}, fmt.Errorf("unable to parse config file at %q: %w", filePath, err)
 		}
@@ -355,8 +395,29 @@ func (p *ConfigParser) LoadAndMergeConfigs(ctx context.Context, filePaths []stri
 	return configs[0], nil
 }
 
+// readConfigFile reads a config file from the local filesystem, or from a
+// blob store if the path is a remote location such as an HTTP(S) URL.
+func readConfigFile(ctx context.Context, path string) ([]byte, error) {
+	if !blob.IsRemote(path) {
+		return os.ReadFile(path)
+	}
+	obj, err := blob.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+	return obj.Data, nil
+}
+
 // GetPathsFromConfigFolder loads all YAML files from a directory and merges them
 func GetPathsFromConfigFolder(ctx context.Context, folderPath string) ([]string, error) {
+	if blob.IsRemote(folderPath) {
+		allFiles, err := blob.List(ctx, folderPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list config folder at %q: %w", folderPath, err)
+		}
+		return allFiles, nil
+	}
+
 	// Check if directory exists
 	info, err := os.Stat(folderPath)
 	if err != nil {
@@ -16,6 +16,10 @@ package internal
 
 import (
 	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -762,6 +766,174 @@ func TestParseConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "tool with constraints",
+			in: `
+			kind: tool
+			name: example_tool
+			type: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: SELECT * FROM users WHERE id = $1 OR email = $2;
+			parameters:
+				- name: id
+					type: string
+					description: the id
+					required: false
+				- name: email
+					type: string
+					description: the email
+					required: false
+			constraints:
+				- exactlyOneOf: [id, email]
+			`,
+			wantConfig: Config{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.ConstrainedConfig{
+						ToolConfig: postgressql.Config{
+							Name:        "example_tool",
+							Type:        "postgres-sql",
+							Source:      "my-pg-instance",
+							Description: "some description",
+							Statement:   "SELECT * FROM users WHERE id = $1 OR email = $2;",
+							Parameters: []parameters.Parameter{
+								parameters.NewStringParameterWithRequired("id", "the id", false),
+								parameters.NewStringParameterWithRequired("email", "the email", false),
+							},
+							AuthRequired: []string{},
+						},
+						Constraints: tools.Constraints{
+							{ExactlyOneOf: []string{"id", "email"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "tool and toolset with policies",
+			in: `
+kind: tool
+name: example_tool
+type: postgres-sql
+source: my-pg-instance
+description: some description
+statement: SELECT 1;
+authRequired:
+  - my-auth
+policies:
+  - claim: groups
+    contains: data-eng
+---
+kind: toolset
+name: example_toolset
+tools:
+  - example_tool
+policies:
+  - authService: my-auth
+    claim: email
+    endsWith: "@example.com"
+`,
+			wantConfig: Config{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.PolicyConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Type:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT 1;",
+							AuthRequired: []string{"my-auth"},
+						},
+						Policies: tools.Policies{
+							{Claim: "groups", Contains: "data-eng"},
+						},
+					},
+				},
+				Toolsets: server.ToolsetConfigs{
+					"example_toolset": tools.ToolsetConfig{
+						Name:      "example_toolset",
+						ToolNames: []string{"example_tool"},
+						Policies: tools.Policies{
+							{AuthService: "my-auth", Claim: "email", EndsWith: "@example.com"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "tool with output",
+			in: `
+kind: tool
+name: example_tool
+type: postgres-sql
+source: my-pg-instance
+description: some description
+statement: SELECT 1;
+output:
+  path: $.rows
+  flatten: true
+  drop:
+    - ssn
+  rename:
+    name: customer
+  limit: 20
+  format: markdown
+`,
+			wantConfig: Config{
+				Tools: server.ToolConfigs{
+					"example_tool": tools.OutputConfig{
+						ToolConfig: postgressql.Config{
+							Name:         "example_tool",
+							Type:         "postgres-sql",
+							Source:       "my-pg-instance",
+							Description:  "some description",
+							Statement:    "SELECT 1;",
+							AuthRequired: []string{},
+						},
+						Output: tools.Output{
+							Path:    "$.rows",
+							Flatten: true,
+							Drop:    []string{"ssn"},
+							Rename:  map[string]string{"name": "customer"},
+							Limit:   20,
+							Format:  tools.OutputFormatMarkdown,
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "generic auth service with static JWKS",
+			in: `
+			kind: authService
+			name: my-generic-auth
+			type: generic
+			audiences:
+				- aud-a
+				- aud-b
+			issuer: https://issuer.example.com
+			algorithms:
+				- RS256
+			leeway: 30s
+			requiredClaims:
+				- email
+			jwksUri: https://issuer.example.com/jwks
+			`,
+			wantConfig: Config{
+				AuthServices: server.AuthServiceConfigs{
+					"my-generic-auth": generic.Config{
+						Name:           "my-generic-auth",
+						Type:           generic.AuthServiceType,
+						Audiences:      []string{"aud-a", "aud-b"},
+						Issuer:         "https://issuer.example.com",
+						Algorithms:     []string{"RS256"},
+						Leeway:         "30s",
+						RequiredClaims: []string{"email"},
+						JwksUri:        "https://issuer.example.com/jwks",
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -2247,3 +2419,46 @@ tools:
 		})
 	}
 }
+
+func TestLoadAndMergeRemoteConfigs(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	remote := `
+kind: toolset
+name: remote-toolset
+tools: []
+`
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.URL.Path != "/tools.yaml" {
+			nethttp.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(remote))
+	}))
+	defer srv.Close()
+
+	local := filepath.Join(t.TempDir(), "local.yaml")
+	if err := os.WriteFile(local, []byte("kind: toolset\nname: local-toolset\ntools: []\n"), 0644); err != nil {
+		t.Fatalf("unable to write local config: %s", err)
+	}
+
+	parser := ConfigParser{}
+	got, err := parser.LoadAndMergeConfigs(ctx, []string{srv.URL + "/tools.yaml", local})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, name := range []string{"remote-toolset", "local-toolset"} {
+		if _, ok := got.Toolsets[name]; !ok {
+			t.Errorf("expected toolset %q to be loaded", name)
+		}
+	}
+
+	_, err = parser.LoadAndMergeConfigs(ctx, []string{srv.URL + "/missing.yaml"})
+	if err == nil || !strings.Contains(err.Error(), "unable to read config file") {
+		t.Fatalf("expected read error for missing remote config, got %v", err)
+	}
+}
@@ -40,11 +40,11 @@ func PersistentFlags(parentCmd *cobra.Command, opts *ToolboxOptions) {
 // ConfigFileFlags defines flags related to the configuration file.
 // It should be applied to any command that requires configuration loading.
 func ConfigFileFlags(flags *pflag.FlagSet, opts *ToolboxOptions) {
-	flags.StringVar(&opts.Config, "config", "", "File path specifying the tool configuration. Cannot be used with --configs, or --config-folder.")
-	flags.StringVar(&opts.Config, "tools-file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
+	flags.StringVar(&opts.Config, "config", "", "File path or HTTP(S) URL specifying the tool configuration. Cannot be used with --configs, or --config-folder.")
+	flags.StringVar(&opts.Config, "tools-file", "", "File path or HTTP(S) URL specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
 	_ = flags.MarkDeprecated("tools-file", "please use --config instead") // DEPRECATED
-	flags.StringSliceVar(&opts.Configs, "configs", []string{}, "Multiple file paths specifying tool configurations. Files will be merged. Cannot be used with --config, or --config-folder.")
-	flags.StringSliceVar(&opts.Configs, "tools-files", []string{}, "Multiple file paths specifying tool configurations. Files will be merged. Cannot be used with --tools-file, or --tools-folder.")
+	flags.StringSliceVar(&opts.Configs, "configs", []string{}, "Multiple file paths or HTTP(S) URLs specifying tool configurations. Files will be merged. Cannot be used with --config, or --config-folder.")
+	flags.StringSliceVar(&opts.Configs, "tools-files", []string{}, "Multiple file paths or HTTP(S) URLs specifying tool configurations. Files will be merged. Cannot be used with --tools-file, or --tools-folder.")
 	_ = flags.MarkDeprecated("tools-files", "please use --configs instead") // DEPRECATED
 	flags.StringVar(&opts.ConfigFolder, "config-folder", "", "Directory path containing YAML tool configuration files. All .yaml and .yml files in the directory will be loaded and merged. Cannot be used with --config, or --configs.")
 	flags.StringVar(&opts.ConfigFolder, "tools-folder", "", "Directory path containing YAML tool configuration files. All .yaml and .yml files in the directory will be loaded and merged. Cannot be used with --tools-file, or --tools-files.")
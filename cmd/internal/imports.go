@@ -17,6 +17,7 @@ package internal
 import (
 	// Import prompt packages for side effect of registration
 	_ "github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	_ "github.com/googleapis/genai-toolbox/internal/prompts/sqlprompt"
 
 	// Import tool packages for side effect of registration
 	_ "github.com/googleapis/genai-toolbox/internal/tools/alloydb/alloydbcreatecluster"
@@ -83,6 +84,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/cockroachdb/cockroachdblistschemas"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/cockroachdb/cockroachdblisttables"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/cockroachdb/cockroachdbsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/composite"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/couchbase"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dataform/dataformcompilelocal"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dataplex/dataplexlookupcontext"
@@ -180,6 +182,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresdatabaseoverview"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresgetcolumncardinality"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresingest"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgreslistactivequeries"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgreslistavailableextensions"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgreslistdatabasestats"
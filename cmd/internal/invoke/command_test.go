@@ -25,6 +25,7 @@ import (
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerysql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/composite"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitesql"
 	"github.com/spf13/cobra"
 )
@@ -77,6 +78,32 @@ tools:
       - name: value
         type: integer
         description: int value
+  csv-tool:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: "csv tool"
+    statement: "SELECT 'hello' as greeting, 'secret' as token"
+    output:
+      drop:
+        - token
+      format: csv
+  greet-composite:
+    kind: composite
+    description: "composite tool"
+    parameters:
+      - name: name
+        type: string
+        description: name to greet
+    steps:
+      - name: hello
+        tool: hello-sqlite
+      - name: echo
+        tool: echo-tool
+        params:
+          message: $.params.name
+    result:
+      greeting: $.steps.hello[0].greeting
+      name: $.steps.echo[0].msg
 `
 
 	toolsFilePath := filepath.Join(tmpDir, "tools.yaml")
@@ -106,6 +133,17 @@ tools:
 			args: []string{"invoke", "int-tool", `{"value": 42}`, "--tools-file", toolsFilePath},
 			want: `"val": 42`,
 		},
+		{
+			desc: "success - composite tool call",
+			args: []string{"invoke", "greet-composite", `{"name": "world"}`, "--config", toolsFilePath},
+			want: `"greeting": "hello",
+  "name": "world"`,
+		},
+		{
+			desc: "success - tool call with output",
+			args: []string{"invoke", "csv-tool", "--config", toolsFilePath},
+			want: "greeting\nhello\n",
+		},
 		{
 			desc:    "error - tool not found",
 			args:    []string{"invoke", "non-existent", "--config", toolsFilePath},
@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/cmd/internal"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prompt <prompt-name> [args]",
+		Short: "Render a prompt directly",
+		Long: `Render the messages of a prompt directly with arguments.
+Args must be a JSON string.
+Example:
+  toolbox prompt my-prompt '{"arg1": "value1"}'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return runPrompt(c, args, opts)
+		},
+	}
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(flags, opts)
+	return cmd
+}
+
+func runPrompt(cmd *cobra.Command, args []string, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	_, err = opts.LoadConfig(ctx, &internal.ConfigParser{})
+	if err != nil {
+		return err
+	}
+
+	// Initialize Resources
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.InitializeConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	resourceMgr := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+
+	// Render Prompt
+	promptName := args[0]
+	prompt, ok := resourceMgr.GetPrompt(promptName)
+	if !ok {
+		errMsg := fmt.Errorf("prompt %q not found", promptName)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	var argsInput string
+	if len(args) > 1 {
+		argsInput = args[1]
+	}
+
+	promptArgs := make(map[string]any)
+	if argsInput != "" {
+		if err := util.DecodeJSON(strings.NewReader(argsInput), &promptArgs); err != nil {
+			errMsg := fmt.Errorf("args must be a valid JSON string: %w", err)
+			opts.Logger.ErrorContext(ctx, errMsg.Error())
+			return errMsg
+		}
+	}
+
+	// Prompts restricted to some callers and arguments bound to auth services
+	// are not supported for ephemeral CLI calls, as there are no verified
+	// claims
+	if err := prompts.Authorize(prompt, nil); err != nil {
+		errMsg := fmt.Errorf("prompt authorization failed: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	argValues, err := prompt.ParseArgs(promptArgs, nil)
+	if err != nil {
+		errMsg := fmt.Errorf("invalid arguments: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	messages, err := prompts.GetMessages(ctx, prompt, resourceMgr, argValues, mcputil.PromptToolRunner(resourceMgr, http.Header{}))
+	if err != nil {
+		errMsg := fmt.Errorf("prompt rendering failed: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	// Print Messages
+	rendered := make([]prompts.RenderedMessage, len(messages))
+	for i, msg := range messages {
+		rendered[i] = msg.Rendered()
+	}
+	res := struct {
+		Description string                    `json:"description,omitempty"`
+		Messages    []prompts.RenderedMessage `json:"messages"`
+	}{
+		Description: prompt.Manifest().Description,
+		Messages:    rendered,
+	}
+	output, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		errMsg := fmt.Errorf("failed to marshal messages: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	fmt.Fprintln(opts.IOStreams.Out, string(output))
+
+	return nil
+}
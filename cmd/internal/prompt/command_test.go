@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/cmd/internal"
+	_ "github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	_ "github.com/googleapis/genai-toolbox/internal/prompts/sqlprompt"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/spf13/cobra"
+)
+
+func promptCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{Use: "toolbox"}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+func TestRenderPrompt(t *testing.T) {
+	// Create a temporary config
+	tmpDir := t.TempDir()
+
+	toolsFileContent := fmt.Sprintf(`
+sources:
+  my-sqlite:
+    kind: sqlite
+    database: %s
+prompts:
+  greet:
+    description: "greeting prompt"
+    messages:
+      - content: "Hello {{.name}}!"
+    arguments:
+      - name: name
+        description: the name to greet
+  count:
+    kind: sql
+    source: my-sqlite
+    description: "counting prompt"
+    statement: "SELECT ? + 1 AS next"
+    messages:
+      - content: "{{range .rows}}Count to {{.next}}.{{end}}"
+    arguments:
+      - name: start
+        type: integer
+        description: the number to start from
+`, filepath.Join(tmpDir, "test.db"))
+
+	toolsFilePath := filepath.Join(tmpDir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		args    []string
+		want    []string
+		wantErr bool
+		errStr  string
+	}{
+		{
+			desc: "success - custom prompt",
+			args: []string{"prompt", "greet", `{"name": "world"}`, "--config", toolsFilePath},
+			want: []string{`"description": "greeting prompt"`, `"role": "user"`, `"text": "Hello world!"`},
+		},
+		{
+			desc: "success - sql prompt",
+			args: []string{"prompt", "count", `{"start": 41}`, "--config", toolsFilePath},
+			want: []string{`"text": "Count to 42."`},
+		},
+		{
+			desc:    "error - prompt not found",
+			args:    []string{"prompt", "non-existent", "--config", toolsFilePath},
+			wantErr: true,
+			errStr:  `prompt "non-existent" not found`,
+		},
+		{
+			desc:    "error - invalid JSON args",
+			args:    []string{"prompt", "greet", `invalid-json`, "--config", toolsFilePath},
+			wantErr: true,
+			errStr:  `args must be a valid JSON string`,
+		},
+		{
+			desc:    "error - missing required argument",
+			args:    []string{"prompt", "greet", "--config", toolsFilePath},
+			wantErr: true,
+			errStr:  `invalid arguments`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := promptCommand(tc.args)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr && !strings.Contains(err.Error(), tc.errStr) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.errStr)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Fatalf("got %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
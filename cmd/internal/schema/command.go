@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/genai-toolbox/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd is the command for generating the configuration JSON Schema.
+type schemaCmd struct {
+	*cobra.Command
+	output string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &schemaCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "schema",
+		Short: "Generate a JSON Schema for the configuration format",
+		Long: `Generate a JSON Schema covering every registered source, tool, auth service,
+embedding model and prompt type. The schema validates each document of a
+configuration file and can be used by editors for autocompletion.
+Example:
+  toolbox schema --output toolbox.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&cmd.output, "output", "", "File path to write the schema to. If not provided, the schema is printed to stdout.")
+	return cmd.Command
+}
+
+func run(cmd *schemaCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	s, err := Generate(ctx)
+	if err != nil {
+		errMsg := fmt.Errorf("unable to generate schema: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		errMsg := fmt.Errorf("failed to marshal schema: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	if cmd.output == "" {
+		fmt.Fprintln(opts.IOStreams.Out, string(b))
+		return nil
+	}
+	if err := os.WriteFile(cmd.output, append(b, '\n'), 0644); err != nil {
+		errMsg := fmt.Errorf("failed to write schema to %q: %w", cmd.output, err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("Schema written to %s", cmd.output))
+	return nil
+}
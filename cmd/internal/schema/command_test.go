@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/cmd/internal"
+	_ "github.com/googleapis/genai-toolbox/internal/prompts/custom"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func schemaCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{Use: "toolbox"}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+func definition(t *testing.T, s map[string]any, name string) map[string]any {
+	t.Helper()
+	defs, ok := s["definitions"].(map[string]any)
+	if !ok {
+		t.Fatalf("schema is missing definitions")
+	}
+	def, ok := defs[name].(map[string]any)
+	if !ok {
+		t.Fatalf("schema is missing definition %q", name)
+	}
+	return def
+}
+
+func requiredFields(def map[string]any) []string {
+	var got []string
+	for _, r := range def["required"].([]any) {
+		got = append(got, r.(string))
+	}
+	return got
+}
+
+func TestSchemaCommand(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "toolbox.schema.json")
+	if _, err := schemaCommand([]string{"schema", "--output", outputPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("unable to read schema: %v", err)
+	}
+	var s map[string]any
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if s["$schema"] != draft07 {
+		t.Fatalf("unexpected $schema: %v", s["$schema"])
+	}
+
+	for _, name := range []string{"source", "authService", "embeddingModel", "tool", "toolset", "prompt", "parameter", "promptArgument", "include", "parameterSet", "parameterSetReference", "toolTemplate", "promptPartial", "authService.generic", "embeddingModel.gemini", "prompt.custom"} {
+		definition(t, s, name)
+	}
+
+	src := definition(t, s, "source.sqlite")
+	if src["additionalProperties"] != false {
+		t.Errorf("source.sqlite should not allow additional properties")
+	}
+	if got := requiredFields(src); !slices.Contains(got, "database") || !slices.Contains(got, "type") {
+		t.Errorf("source.sqlite required = %v, want database and type", got)
+	}
+
+	model := definition(t, s, "embeddingModel.gemini")
+	for _, field := range []string{"cache", "batch"} {
+		if _, ok := model["properties"].(map[string]any)[field]; !ok {
+			t.Errorf("embeddingModel.gemini is missing property %q", field)
+		}
+	}
+
+	tool := definition(t, s, "tool.sqlite-sql")
+	props := tool["properties"].(map[string]any)
+	for _, field := range []string{"kind", "name", "type", "source", "description", "statement", "parameters", "authRequired", "constraints", "policies", "requireConfirmation", "output"} {
+		if _, ok := props[field]; !ok {
+			t.Errorf("tool.sqlite-sql is missing property %q", field)
+		}
+	}
+	params := props["parameters"].(map[string]any)
+	items := params["items"].(map[string]any)["anyOf"].([]any)
+	if ref := items[0].(map[string]any)["$ref"]; ref != "#/definitions/parameter" {
+		t.Errorf("parameters items = %v, want reference to parameter definition", ref)
+	}
+	if ref := items[1].(map[string]any)["$ref"]; ref != "#/definitions/parameterSetReference" {
+		t.Errorf("parameters items = %v, want reference to parameter set", ref)
+	}
+	if got := requiredFields(tool); !slices.Contains(got, "statement") {
+		t.Errorf("tool.sqlite-sql required = %v, want statement", got)
+	}
+
+	// prompts default to the custom type, so `type` is optional
+	if got := requiredFields(definition(t, s, "prompt.custom")); slices.Contains(got, "type") {
+		t.Errorf("prompt.custom should not require type, got %v", got)
+	}
+}
+
+func TestSchemaCommandStdout(t *testing.T) {
+	out, err := schemaCommand([]string{"schema"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Contains([]byte(out), []byte(`"tool.sqlite-sql"`)) {
+		t.Fatalf("expected schema output to contain sqlite-sql tool definition, got %q", out)
+	}
+}
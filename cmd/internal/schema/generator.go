@@ -0,0 +1,502 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
+	"github.com/googleapis/genai-toolbox/internal/auth/generic"
+	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/auth/introspection"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/gemini"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const draft07 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document. A map is used so that the generated
+// output only contains the keywords that are relevant to each node.
+type Schema map[string]any
+
+// authServiceConfigs lists the supported auth service types. Auth services
+// are not registered through a registry, so new types must be added here.
+var authServiceConfigs = map[string]reflect.Type{
+	google.AuthServiceType:        reflect.TypeFor[google.Config](),
+	generic.AuthServiceType:       reflect.TypeFor[generic.Config](),
+	introspection.AuthServiceType: reflect.TypeFor[introspection.Config](),
+	apikey.AuthServiceType:        reflect.TypeFor[apikey.Config](),
+}
+
+// embeddingModelConfigs lists the supported embedding model types. Embedding
+// models are not registered through a registry, so new types must be added
+// here.
+var embeddingModelConfigs = map[string]reflect.Type{
+	gemini.EmbeddingModelType: reflect.TypeFor[gemini.Config](),
+	openai.EmbeddingModelType: reflect.TypeFor[openai.Config](),
+}
+
+// defaultPromptType is used by prompts.DecodeConfig when `type` is omitted.
+const defaultPromptType = "custom"
+
+var (
+	parameterType  = reflect.TypeFor[parameters.Parameter]()
+	parametersType = reflect.TypeFor[parameters.Parameters]()
+	argumentsType  = reflect.TypeFor[prompts.Arguments]()
+	messageType    = reflect.TypeFor[prompts.Message]()
+	durationType   = reflect.TypeFor[time.Duration]()
+)
+
+// unmarshalerTypes are the interfaces that allow a type to decode itself from
+// YAML. The shape of such types can't be derived from their Go fields.
+var unmarshalerTypes = []reflect.Type{
+	reflect.TypeFor[yaml.InterfaceUnmarshaler](),
+	reflect.TypeFor[yaml.InterfaceUnmarshalerContext](),
+	reflect.TypeFor[yaml.BytesUnmarshaler](),
+	reflect.TypeFor[yaml.BytesUnmarshalerContext](),
+}
+
+// generator builds a JSON Schema for the flat (v2) configuration format.
+type generator struct {
+	definitions Schema
+	// visiting guards against infinitely recursive struct types
+	visiting map[reflect.Type]bool
+}
+
+// Generate walks the source, tool and prompt registries along with the known
+// auth service and embedding model types, and returns a JSON Schema that
+// validates a single configuration document. Documents are discriminated on
+// `kind`, and each resource is further discriminated on `type`.
+func Generate(ctx context.Context) (Schema, error) {
+	g := &generator{definitions: Schema{}, visiting: map[reflect.Type]bool{}}
+
+	sourceTypes, err := prototypes(ctx, sources.RegisteredTypes(), func(ctx context.Context, t string, dec *yaml.Decoder) (any, error) {
+		return sources.DecodeConfig(ctx, t, "", dec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	toolTypes, err := prototypes(ctx, tools.RegisteredTypes(), func(ctx context.Context, t string, dec *yaml.Decoder) (any, error) {
+		return tools.DecodeConfig(ctx, t, "", dec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	promptTypes, err := prototypes(ctx, prompts.RegisteredTypes(), func(ctx context.Context, t string, dec *yaml.Decoder) (any, error) {
+		return prompts.DecodeConfig(ctx, t, "", dec)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.definitions["parameter"] = g.parameterSchema("")
+	g.definitions["promptArgument"] = g.parameterSchema(parameters.TypeString)
+
+	g.definitions["source"] = g.resourceSchema("source", sourceTypes, "")
+	g.definitions["authService"] = g.resourceSchema("authService", authServiceConfigs, "")
+	g.definitions["embeddingModel"] = g.resourceSchema("embeddingModel", embeddingModelConfigs, "")
+	// cache and batch are decoded by the server for every embedding model type
+	cache := g.typeSchema(reflect.TypeFor[embeddingmodels.CacheOptions]())
+	batch := g.typeSchema(reflect.TypeFor[embeddingmodels.BatchOptions]())
+	for t := range embeddingModelConfigs {
+		props := g.definitions["embeddingModel."+t].(Schema)["properties"].(Schema)
+		props["cache"] = cache
+		props["batch"] = batch
+	}
+	toolSchema := g.resourceSchema("tool", toolTypes, "")
+	// constraints, policies, requireConfirmation and output are decoded by
+	// the server for every tool type
+	constraints := g.typeSchema(reflect.TypeFor[tools.Constraints]())
+	policies := g.typeSchema(reflect.TypeFor[tools.Policies]())
+	output := g.typeSchema(reflect.TypeFor[tools.Output]())
+	for t := range toolTypes {
+		props := g.definitions["tool."+t].(Schema)["properties"].(Schema)
+		props["constraints"] = constraints
+		props["policies"] = policies
+		props["requireConfirmation"] = Schema{"type": "boolean"}
+		props["output"] = output
+	}
+	// tools using a template only set the fields they override, so they
+	// can't be validated against their type until the template is applied
+	g.definitions["tool"] = Schema{
+		"if":   Schema{"required": []string{"template"}},
+		"then": Schema{"properties": Schema{"template": Schema{"type": "string"}}},
+		"else": toolSchema,
+	}
+	g.definitions["prompt"] = g.resourceSchema("prompt", promptTypes, defaultPromptType)
+	g.definitions["toolset"] = Schema{
+		"type":     "object",
+		"required": []string{"kind", "name", "tools"},
+		"properties": Schema{
+			"kind": Schema{"const": "toolset"},
+			"name": Schema{"type": "string"},
+			"tools": Schema{
+				"type":  "array",
+				"items": Schema{"type": "string"},
+			},
+			"policies": policies,
+		},
+		"additionalProperties": false,
+	}
+
+	g.definitions["include"] = Schema{
+		"type":     "object",
+		"required": []string{"kind", "files"},
+		"properties": Schema{
+			"kind": Schema{"const": "include"},
+			"files": Schema{
+				"type":     "array",
+				"minItems": 1,
+				"items":    Schema{"type": "string"},
+			},
+		},
+		"additionalProperties": false,
+	}
+	g.definitions["parameterSet"] = Schema{
+		"type":     "object",
+		"required": []string{"kind", "name", "parameters"},
+		"properties": Schema{
+			"kind":       Schema{"const": "parameterSet"},
+			"name":       Schema{"type": "string"},
+			"parameters": g.typeSchema(parametersType),
+		},
+		"additionalProperties": false,
+	}
+	g.definitions["parameterSetReference"] = Schema{
+		"type":                 "object",
+		"required":             []string{"parameterSet"},
+		"properties":           Schema{"parameterSet": Schema{"type": "string"}},
+		"additionalProperties": false,
+	}
+	// templates hold any subset of a tool's fields
+	g.definitions["toolTemplate"] = Schema{
+		"type":     "object",
+		"required": []string{"kind", "name"},
+		"properties": Schema{
+			"kind":     Schema{"const": "toolTemplate"},
+			"name":     Schema{"type": "string"},
+			"template": Schema{"type": "string"},
+		},
+	}
+
+	g.definitions["promptPartial"] = Schema{
+		"type":     "object",
+		"required": []string{"kind", "name", "template"},
+		"properties": Schema{
+			"kind":     Schema{"const": "promptPartial"},
+			"name":     Schema{"type": "string"},
+			"template": Schema{"type": "string"},
+		},
+		"additionalProperties": false,
+	}
+
+	kinds := []string{"source", "authService", "embeddingModel", "tool", "toolset", "prompt", "include", "parameterSet", "toolTemplate", "promptPartial"}
+	allOf := make([]any, 0, len(kinds)+1)
+	// every document except includes is named
+	allOf = append(allOf, Schema{
+		"if":   Schema{"properties": Schema{"kind": Schema{"const": "include"}}},
+		"else": Schema{"required": []string{"name"}},
+	})
+	for _, kind := range kinds {
+		allOf = append(allOf, Schema{
+			"if": Schema{
+				"required":   []string{"kind"},
+				"properties": Schema{"kind": Schema{"const": kind}},
+			},
+			"then": ref(kind),
+		})
+	}
+
+	return Schema{
+		"$schema":     draft07,
+		"title":       "MCP Toolbox configuration",
+		"description": "A single document of a Toolbox configuration file.",
+		"type":        "object",
+		"required":    []string{"kind"},
+		"properties": Schema{
+			"kind": Schema{"enum": kinds},
+			"name": Schema{"type": "string"},
+		},
+		"allOf":       allOf,
+		"definitions": g.definitions,
+	}, nil
+}
+
+// prototypes recovers the config struct decoded by the factory of every
+// registered type. An empty document is decoded with a typeRecorder, which
+// captures the struct type before the factory gets to validate it.
+func prototypes(ctx context.Context, types []string, decode func(context.Context, string, *yaml.Decoder) (any, error)) (map[string]reflect.Type, error) {
+	protos := make(map[string]reflect.Type, len(types))
+	for _, t := range types {
+		r := &typeRecorder{}
+		dec := yaml.NewDecoder(strings.NewReader("{}"), yaml.Validator(r))
+		if _, err := decode(ctx, t, dec); r.t == nil {
+			return nil, fmt.Errorf("unable to build schema for type %q: %w", t, err)
+		}
+		protos[t] = r.t
+	}
+	return protos, nil
+}
+
+var errTypeRecorded = errors.New("type recorded")
+
+// typeRecorder is a yaml.StructValidator that records the type of the first
+// struct decoded and aborts decoding.
+type typeRecorder struct {
+	t reflect.Type
+}
+
+func (r *typeRecorder) Struct(v any) error {
+	if r.t == nil {
+		r.t = reflect.TypeOf(v)
+	}
+	return errTypeRecorded
+}
+
+// resourceSchema builds a definition for one kind of resource, discriminated
+// on its `type` field. If defaultType is set, documents omitting `type` are
+// validated against that type.
+func (g *generator) resourceSchema(kind string, configs map[string]reflect.Type, defaultType string) Schema {
+	types := make([]string, 0, len(configs))
+	for t := range configs {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+
+	allOf := make([]any, 0, len(types))
+	for _, t := range types {
+		defName := kind + "." + t
+		def := g.objectSchema(configs[t])
+		props := def["properties"].(Schema)
+		props["kind"] = Schema{"const": kind}
+		props["type"] = Schema{"const": t}
+		def["required"] = without(def["required"].([]string), "name", "type")
+		if t != defaultType {
+			def["required"] = append(def["required"].([]string), "type")
+		}
+		g.definitions[defName] = def
+
+		cond := Schema{"properties": Schema{"type": Schema{"const": t}}}
+		if t != defaultType {
+			cond["required"] = []string{"type"}
+		}
+		allOf = append(allOf, Schema{"if": cond, "then": ref(defName)})
+	}
+
+	s := Schema{
+		"type":       "object",
+		"properties": Schema{"type": Schema{"enum": types}},
+		"allOf":      allOf,
+	}
+	if defaultType == "" {
+		s["required"] = []string{"type"}
+	}
+	return s
+}
+
+// parameterSchema builds a definition for tool parameters (and prompt
+// arguments), discriminated on the parameter `type` field.
+func (g *generator) parameterSchema(defaultType string) Schema {
+	paramTypes := map[string]reflect.Type{
+		parameters.TypeString: reflect.TypeFor[parameters.StringParameter](),
+		parameters.TypeInt:    reflect.TypeFor[parameters.IntParameter](),
+		parameters.TypeFloat:  reflect.TypeFor[parameters.FloatParameter](),
+		parameters.TypeBool:   reflect.TypeFor[parameters.BooleanParameter](),
+		parameters.TypeArray:  reflect.TypeFor[parameters.ArrayParameter](),
+		parameters.TypeMap:    reflect.TypeFor[parameters.MapParameter](),
+	}
+	types := make([]string, 0, len(paramTypes))
+	for t := range paramTypes {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+
+	allOf := make([]any, 0, len(types))
+	for _, t := range types {
+		def := g.objectSchema(paramTypes[t])
+		props := def["properties"].(Schema)
+		props["type"] = Schema{"const": t}
+		// defaults can also be computed at invocation time, see
+		// parameters.ParseParameter
+		if t != parameters.TypeMap {
+			props["default"] = Schema{"anyOf": []any{props["default"], g.typeSchema(reflect.TypeFor[parameters.ContextDefault]())}}
+		}
+		if t == defaultType {
+			def["required"] = without(def["required"].([]string), "type")
+		}
+		cond := Schema{"properties": Schema{"type": Schema{"const": t}}}
+		if t != defaultType {
+			cond["required"] = []string{"type"}
+		}
+		allOf = append(allOf, Schema{"if": cond, "then": def})
+	}
+
+	s := Schema{
+		"type":       "object",
+		"properties": Schema{"type": Schema{"enum": types}},
+		"allOf":      allOf,
+	}
+	if defaultType == "" {
+		s["required"] = []string{"type"}
+	}
+	return s
+}
+
+// objectSchema builds a closed object schema from the yaml-tagged fields of
+// a struct. Fields tagged `validate:"required"` are listed as required.
+func (g *generator) objectSchema(t reflect.Type) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	props := Schema{}
+	required := []string{}
+	g.collectFields(t, props, &required)
+	return Schema{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (g *generator) collectFields(t reflect.Type, props Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "" {
+			tag = f.Tag.Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		if slices.Contains(opts[1:], "inline") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.collectFields(ft, props, required)
+				continue
+			}
+		}
+		name := opts[0]
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fieldSchema := g.typeSchema(f.Type)
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			switch {
+			case rule == "required":
+				*required = append(*required, name)
+			case strings.HasPrefix(rule, "oneof="):
+				fieldSchema["enum"] = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			}
+		}
+		props[name] = fieldSchema
+	}
+}
+
+// typeSchema maps a Go type to the JSON Schema accepted by the YAML decoder.
+func (g *generator) typeSchema(t reflect.Type) Schema {
+	switch t {
+	case parameterType:
+		return ref("parameter")
+	case parametersType:
+		return Schema{"type": "array", "items": Schema{"anyOf": []any{ref("parameter"), ref("parameterSetReference")}}}
+	case argumentsType:
+		return Schema{"type": "array", "items": ref("promptArgument")}
+	case durationType:
+		return Schema{"type": []string{"string", "integer"}}
+	}
+	if t != messageType && isUnmarshaler(t) {
+		// Custom unmarshalers for string types (e.g. enums) still accept a
+		// string; anything else is left unconstrained.
+		if t.Kind() == reflect.String {
+			return Schema{"type": "string"}
+		}
+		return Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if g.visiting[t] {
+			return Schema{"type": "object"}
+		}
+		g.visiting[t] = true
+		defer delete(g.visiting, t)
+		return g.objectSchema(t)
+	default:
+		// interfaces and other dynamic values accept anything
+		return Schema{}
+	}
+}
+
+func isUnmarshaler(t reflect.Type) bool {
+	pt := t
+	if t.Kind() != reflect.Pointer {
+		pt = reflect.PointerTo(t)
+	}
+	for _, u := range unmarshalerTypes {
+		if t.Implements(u) || pt.Implements(u) {
+			return true
+		}
+	}
+	return false
+}
+
+func ref(name string) Schema {
+	return Schema{"$ref": "#/definitions/" + name}
+}
+
+func without(list []string, remove ...string) []string {
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if !slices.Contains(remove, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -17,6 +17,7 @@ package cmd
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
@@ -34,16 +35,18 @@ import (
 	"github.com/googleapis/genai-toolbox/cmd/internal"
 	"github.com/googleapis/genai-toolbox/cmd/internal/invoke"
 	"github.com/googleapis/genai-toolbox/cmd/internal/migrate"
+	"github.com/googleapis/genai-toolbox/cmd/internal/prompt"
+	"github.com/googleapis/genai-toolbox/cmd/internal/schema"
 	"github.com/googleapis/genai-toolbox/cmd/internal/serve"
 	"github.com/googleapis/genai-toolbox/cmd/internal/skills"
 	"github.com/googleapis/genai-toolbox/internal/auth"
-	"github.com/googleapis/genai-toolbox/internal/auth/generic"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/blob"
 	"github.com/spf13/cobra"
 )
 
@@ -117,15 +120,17 @@ func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
 	internal.ConfigFileFlags(flags, opts)
 	internal.ServeFlags(flags, opts)
 	flags.BoolVar(&opts.Cfg.DisableReload, "disable-reload", false, "Disables dynamic reloading of tools file.")
-	flags.IntVar(&opts.Cfg.PollInterval, "poll-interval", 0, "Specifies the polling frequency (seconds) for configuration file updates.")
+	flags.IntVar(&opts.Cfg.PollInterval, "poll-interval", 0, "Specifies the polling frequency (seconds) for configuration file updates. Remote configurations are polled every 60 seconds if unset.")
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd, opts) }
 
 	// Register subcommands
 	cmd.AddCommand(invoke.NewCommand(opts))
+	cmd.AddCommand(prompt.NewCommand(opts))
 	cmd.AddCommand(skills.NewCommand(opts))
 	cmd.AddCommand(serve.NewCommand(opts))
 	cmd.AddCommand(migrate.NewCommand(opts))
+	cmd.AddCommand(schema.NewCommand(opts))
 
 	return cmd
 }
@@ -198,7 +203,7 @@ func checkModTime(path string, mTime time.Time, lastSeen map[string]time.Time) b
 }
 
 // Helper to scan watched files and check their modification times in polling system
-func scanWatchedFiles(watchingFolder bool, folderToWatch string, watchedFiles map[string]bool, lastSeen map[string]time.Time) (map[string]bool, bool, error) {
+func scanWatchedFiles(watchingFolder bool, folderToWatch string, watchedFiles map[string]bool, includedFiles map[string]bool, lastSeen map[string]time.Time) (map[string]bool, bool, error) {
 	changed := false
 	currentDiskFiles := make(map[string]bool)
 	if watchingFolder {
@@ -227,11 +232,93 @@ func scanWatchedFiles(watchingFolder bool, folderToWatch string, watchedFiles ma
 			}
 		}
 	}
+	for f := range includedFiles {
+		if info, err := os.Stat(f); err == nil {
+			currentDiskFiles[f] = true
+			if checkModTime(f, info.ModTime(), lastSeen) {
+				changed = true
+			}
+		}
+	}
 	return currentDiskFiles, changed, nil
 }
 
-// watchChanges checks for changes in the provided yaml config(s) or folder.
-func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, s *server.Server, pollTickerSecond int) {
+// defaultRemotePollInterval is the polling frequency (seconds) for remote
+// configs when --poll-interval is not set.
+const defaultRemotePollInterval = 60
+
+// remoteWatcher detects changes in remote config files by polling their
+// version identifiers (e.g. ETags).
+type remoteWatcher struct {
+	// folder is the remote config folder, if one is watched
+	folder string
+	files  []string
+	// includes are the remote files included by the configs
+	includes []string
+	etags    map[string]string
+}
+
+func newRemoteWatcher(watchingFolder bool, folderToWatch string, watchedFiles map[string]bool) *remoteWatcher {
+	r := &remoteWatcher{etags: make(map[string]string)}
+	if watchingFolder {
+		if blob.IsRemote(folderToWatch) {
+			r.folder = folderToWatch
+		}
+		return r
+	}
+	for f := range watchedFiles {
+		if blob.IsRemote(f) {
+			r.files = append(r.files, f)
+		}
+	}
+	return r
+}
+
+// enabled reports whether there are any remote configs to watch.
+func (r *remoteWatcher) enabled() bool {
+	return r.folder != "" || len(r.files) > 0 || len(r.includes) > 0
+}
+
+// poll fetches the remote configs and reports whether any of them were
+// added, modified or deleted since the last poll.
+func (r *remoteWatcher) poll(ctx context.Context) (bool, error) {
+	files := r.files
+	if r.folder != "" {
+		var err error
+		files, err = internal.GetPathsFromConfigFolder(ctx, r.folder)
+		if err != nil {
+			return false, err
+		}
+	}
+	files = append(slices.Clone(files), r.includes...)
+
+	changed := false
+	current := make(map[string]bool)
+	for _, f := range files {
+		current[f] = true
+		obj, err := blob.Get(ctx, f, r.etags[f])
+		if errors.Is(err, blob.ErrNotModified) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("error polling remote config %q: %w", f, err)
+		}
+		r.etags[f] = obj.ETag
+		changed = true
+	}
+	for f := range r.etags {
+		if !current[f] {
+			delete(r.etags, f)
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// watchChanges checks for changes in the provided yaml config(s) or folder,
+// and in the files they include. includes are the files included by the
+// configs when they were loaded, and are updated on every reload.
+func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, includes []string, s *server.Server, pollTickerSecond int) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
@@ -265,6 +352,9 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 	}
 
 	for dir := range watchDirs {
+		if blob.IsRemote(dir) {
+			continue
+		}
 		err := w.Add(dir)
 		if err != nil {
 			logger.WarnContext(ctx, fmt.Sprintf("Error adding path %s to watcher: %s", dir, err))
@@ -273,16 +363,46 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 		logger.DebugContext(ctx, fmt.Sprintf("Added directory %s to watcher.", dir))
 	}
 
+	remote := newRemoteWatcher(watchingFolder, folderToWatch, watchedFiles)
+
+	// includedFiles holds the local included files, whose directories are
+	// added to the watcher as well
+	includedFiles := make(map[string]bool)
+	watchIncludes := func(includes []string) {
+		clear(includedFiles)
+		remote.includes = nil
+		for _, f := range includes {
+			if blob.IsRemote(f) {
+				remote.includes = append(remote.includes, f)
+				continue
+			}
+			cleanFile := filepath.Clean(f)
+			includedFiles[cleanFile] = true
+			dir := filepath.Dir(cleanFile)
+			if watchDirs[dir] {
+				continue
+			}
+			if err := w.Add(dir); err != nil {
+				logger.WarnContext(ctx, fmt.Sprintf("Error adding path %s to watcher: %s", dir, err))
+				continue
+			}
+			watchDirs[dir] = true
+			logger.DebugContext(ctx, fmt.Sprintf("Added directory %s to watcher.", dir))
+		}
+	}
+	watchIncludes(includes)
+
 	lastSeen := make(map[string]time.Time)
 	var pollTickerChan <-chan time.Time
-	if pollTickerSecond > 0 {
+	// remote folders are handled by the remote watcher below
+	if pollTickerSecond > 0 && !(watchingFolder && blob.IsRemote(folderToWatch)) {
 		ticker := time.NewTicker(time.Duration(pollTickerSecond) * time.Second)
 		defer ticker.Stop()
 		pollTickerChan = ticker.C // Assign the channel
 		logger.DebugContext(ctx, fmt.Sprintf("NFS polling enabled every %v", pollTickerSecond))
 
 		// Pre-populate lastSeen to avoid an initial spurious reload
-		_, _, err = scanWatchedFiles(watchingFolder, folderToWatch, watchedFiles, lastSeen)
+		_, _, err = scanWatchedFiles(watchingFolder, folderToWatch, watchedFiles, includedFiles, lastSeen)
 		if err != nil {
 			logger.WarnContext(ctx, err.Error())
 		}
@@ -290,6 +410,33 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 		logger.DebugContext(ctx, "NFS polling disabled (interval is 0)")
 	}
 
+	var remotePollChan <-chan time.Time
+	var remoteTicker *time.Ticker
+	defer func() {
+		if remoteTicker != nil {
+			remoteTicker.Stop()
+		}
+	}()
+	// startRemotePolling is called once there are remote configs to watch,
+	// which may only be included after a reload
+	startRemotePolling := func() {
+		remotePollSecond := pollTickerSecond
+		if remotePollSecond <= 0 {
+			remotePollSecond = defaultRemotePollInterval
+		}
+		remoteTicker = time.NewTicker(time.Duration(remotePollSecond) * time.Second)
+		remotePollChan = remoteTicker.C
+		logger.DebugContext(ctx, fmt.Sprintf("Remote config polling enabled every %v", remotePollSecond))
+
+		// Pre-populate version identifiers to avoid an initial spurious reload
+		if _, err := remote.poll(ctx); err != nil {
+			logger.WarnContext(ctx, err.Error())
+		}
+	}
+	if remote.enabled() {
+		startRemotePolling()
+	}
+
 	// debounce timer is used to prevent multiple writes triggering multiple reloads
 	debounceDelay := 100 * time.Millisecond
 	debounce := time.NewTimer(1 * time.Minute)
@@ -302,7 +449,7 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 			return
 		case <-pollTickerChan:
 			// Get files that are currently on disk
-			currentDiskFiles, changed, err := scanWatchedFiles(watchingFolder, folderToWatch, watchedFiles, lastSeen)
+			currentDiskFiles, changed, err := scanWatchedFiles(watchingFolder, folderToWatch, watchedFiles, includedFiles, lastSeen)
 			if err != nil {
 				logger.WarnContext(ctx, err.Error())
 				continue
@@ -323,6 +470,16 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 				// once this timer runs out, it will trigger debounce.C
 				debounce.Reset(debounceDelay)
 			}
+		case <-remotePollChan:
+			changed, err := remote.poll(ctx)
+			if err != nil {
+				logger.WarnContext(ctx, err.Error())
+				continue
+			}
+			if changed {
+				logger.DebugContext(ctx, "Remote config change detected via polling")
+				debounce.Reset(debounceDelay)
+			}
 		case err, ok := <-w.Errors:
 			if !ok {
 				logger.WarnContext(ctx, "file watcher was closed unexpectedly")
@@ -348,10 +505,10 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 			cleanedFilename := filepath.Clean(e.Name)
 			logger.DebugContext(ctx, fmt.Sprintf("%s event detected in %s", e.Op, cleanedFilename))
 
-			folderChanged := watchingFolder &&
+			folderChanged := watchingFolder && filepath.Dir(cleanedFilename) == folderToWatch &&
 				(strings.HasSuffix(cleanedFilename, ".yaml") || strings.HasSuffix(cleanedFilename, ".yml"))
 
-			if folderChanged || watchedFiles[cleanedFilename] {
+			if folderChanged || watchedFiles[cleanedFilename] || includedFiles[cleanedFilename] {
 				// indicates the write event is on a relevant file
 				debounce.Reset(debounceDelay)
 			}
@@ -372,6 +529,11 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 			}
 			logger.DebugContext(ctx, "Reloading tools file(s).")
 			reloadedConfig, err := parser.LoadAndMergeConfigs(ctx, allFiles)
+			// the included files may have changed, even if the reload failed
+			watchIncludes(parser.Includes())
+			if remotePollChan == nil && remote.enabled() {
+				startRemotePolling()
+			}
 			if err != nil {
 				logger.WarnContext(ctx, fmt.Sprintf("error loading configs %s", err))
 				continue
@@ -399,13 +561,22 @@ func resolveWatcherInputs(toolsFile string, toolsFiles []string, toolsFolder str
 	if len(toolsFiles) > 0 {
 		relevantFiles = toolsFiles
 	} else if toolsFolder != "" {
-		watchDirs[filepath.Clean(toolsFolder)] = true
+		if blob.IsRemote(toolsFolder) {
+			// remote locations are polled rather than added to fsnotify
+			watchDirs[toolsFolder] = true
+		} else {
+			watchDirs[filepath.Clean(toolsFolder)] = true
+		}
 	} else {
 		relevantFiles = []string{toolsFile}
 	}
 
 	// extract parent dir for relevant files and dedup
 	for _, f := range relevantFiles {
+		if blob.IsRemote(f) {
+			watchedFiles[f] = true
+			continue
+		}
 		cleanFile := filepath.Clean(f)
 		watchedFiles[cleanFile] = true
 		watchDirs[filepath.Dir(cleanFile)] = true
@@ -446,14 +617,15 @@ func run(cmd *cobra.Command, opts *internal.ToolboxOptions) error {
 		_ = shutdown(ctx)
 	}()
 
-	isCustomConfigured, err := opts.LoadConfig(ctx, &internal.ConfigParser{})
+	parser := &internal.ConfigParser{}
+	isCustomConfigured, err := opts.LoadConfig(ctx, parser)
 	if err != nil {
 		return err
 	}
 
 	// Validate ToolboxUrl if MCP Auth is enabled
 	for _, authSvc := range opts.Cfg.AuthServiceConfigs {
-		if genCfg, ok := authSvc.(generic.Config); ok && genCfg.McpEnabled {
+		if mcpCfg, ok := authSvc.(auth.MCPAuthServiceConfig); ok && mcpCfg.IsMcpEnabled() {
 			if opts.Cfg.ToolboxUrl == "" {
 				opts.Cfg.ToolboxUrl = os.Getenv("TOOLBOX_URL")
 			}
@@ -508,7 +680,7 @@ func run(cmd *cobra.Command, opts *internal.ToolboxOptions) error {
 	if isCustomConfigured && !opts.Cfg.DisableReload {
 		watchDirs, watchedFiles := resolveWatcherInputs(opts.Config, opts.Configs, opts.ConfigFolder)
 		// start watching the file(s) or folder for changes to trigger dynamic reloading
-		go watchChanges(ctx, watchDirs, watchedFiles, s, opts.Cfg.PollInterval)
+		go watchChanges(ctx, watchDirs, watchedFiles, parser.Includes(), s, opts.Cfg.PollInterval)
 	}
 
 	// wait for either the server to error out or the command's context to be canceled
@@ -20,6 +20,8 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
@@ -528,6 +530,17 @@ func TestResolveWatcherInputs(t *testing.T) {
 			wantWatchDirs:    map[string]bool{"tools_folder": true},
 			wantWatchedFiles: map[string]bool{},
 		},
+		{
+			description:   "remote and local files",
+			toolsFile:     "",
+			toolsFiles:    []string{"https://example.com/tools.yaml", "tools_folder/example_tools.yaml"},
+			toolsFolder:   "",
+			wantWatchDirs: map[string]bool{"tools_folder": true},
+			wantWatchedFiles: map[string]bool{
+				"https://example.com/tools.yaml":  true,
+				"tools_folder/example_tools.yaml": true,
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -547,6 +560,48 @@ func TestResolveWatcherInputs(t *testing.T) {
 	}
 }
 
+func TestRemoteWatcherPoll(t *testing.T) {
+	ctx := context.Background()
+	version := "v1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf("%q", version)
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte("kind: toolset\nname: my-toolset\ntools: []\n"))
+	}))
+	defer srv.Close()
+
+	localFile := filepath.Join("tools_folder", "example_tools.yaml")
+	remoteFile := srv.URL + "/tools.yaml"
+	r := newRemoteWatcher(false, "", map[string]bool{remoteFile: true, localFile: true})
+	if diff := cmp.Diff([]string{remoteFile}, r.files); diff != "" {
+		t.Fatalf("incorrect remote files: diff %v", diff)
+	}
+
+	steps := []struct {
+		desc    string
+		version string
+		want    bool
+	}{
+		{desc: "initial fetch", version: "v1", want: true},
+		{desc: "unchanged", version: "v1", want: false},
+		{desc: "modified", version: "v2", want: true},
+	}
+	for _, step := range steps {
+		version = step.version
+		changed, err := r.poll(ctx)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", step.desc, err)
+		}
+		if changed != step.want {
+			t.Fatalf("%s: got changed=%v, want %v", step.desc, changed, step.want)
+		}
+	}
+}
+
 // helper function for testing file detection in dynamic reloading
 func tmpFileWithCleanup(content []byte) (string, func(), error) {
 	f, err := os.CreateTemp("", "*")
@@ -600,7 +655,7 @@ func TestSingleEdit(t *testing.T) {
 	watchedFiles := map[string]bool{cleanFileToWatch: true}
 	watchDirs := map[string]bool{watchDir: true}
 
-	go watchChanges(ctx, watchDirs, watchedFiles, mockServer, 0)
+	go watchChanges(ctx, watchDirs, watchedFiles, nil, mockServer, 0)
 
 	// escape backslash so regex doesn't fail on windows filepaths
 	regexEscapedPathFile := strings.ReplaceAll(cleanFileToWatch, `\`, `\\\\*\\`)
@@ -628,6 +683,72 @@ func TestSingleEdit(t *testing.T) {
 	}
 }
 
+func TestIncludeEdit(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelCtx()
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	defer pr.Close()
+
+	// the included file lives in another directory than the config file
+	configDir, includeDir := t.TempDir(), t.TempDir()
+	includeFile := filepath.Join(includeDir, "params.yaml")
+	if err := os.WriteFile(includeFile, []byte("kind: parameterSet\nname: empty\nparameters: []\n"), 0644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	configFile := filepath.Join(configDir, "tools.yaml")
+	if err := os.WriteFile(configFile, []byte(fmt.Sprintf("kind: include\nfiles:\n  - %s\n", includeFile)), 0644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	logger, err := log.NewStdLogger(pw, pw, "DEBUG")
+	if err != nil {
+		t.Fatalf("failed to setup logger %s", err)
+	}
+	ctx = util.WithLogger(ctx, logger)
+
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		t.Fatalf("failed to setup instrumentation %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	parser := internal.ConfigParser{}
+	if _, err := parser.LoadAndMergeConfigs(ctx, []string{configFile}); err != nil {
+		t.Fatalf("unexpected error loading config: %s", err)
+	}
+
+	watchDirs, watchedFiles := resolveWatcherInputs(configFile, nil, "")
+	go watchChanges(ctx, watchDirs, watchedFiles, parser.Includes(), &server.Server{}, 0)
+
+	// escape backslash so regex doesn't fail on windows filepaths
+	regexEscapedPathFile := strings.ReplaceAll(includeFile, `\`, `\\\\*\\`)
+	regexEscapedPathFile = path.Clean(regexEscapedPathFile)
+
+	regexEscapedPathDir := strings.ReplaceAll(includeDir, `\`, `\\\\*\\`)
+	regexEscapedPathDir = path.Clean(regexEscapedPathDir)
+
+	begunWatchingDir := regexp.MustCompile(fmt.Sprintf(`DEBUG "Added directory %s to watcher."`, regexEscapedPathDir))
+	if _, err := testutils.WaitForString(ctx, begunWatchingDir, pr); err != nil {
+		t.Fatalf("timeout or error waiting for watcher to start: %s", err)
+	}
+
+	// an invalid include fails the reload, which leaves the server as is
+	if err := os.WriteFile(includeFile, []byte("kind: [\n"), 0644); err != nil {
+		t.Fatalf("error writing to file: %v", err)
+	}
+
+	detectedFileChange := regexp.MustCompile(fmt.Sprintf(`event detected in %s"`, regexEscapedPathFile))
+	if _, err := testutils.WaitForString(ctx, detectedFileChange, pr); err != nil {
+		t.Fatalf("timeout or error waiting for file to detect write: %s", err)
+	}
+	reloadFailed := regexp.MustCompile(`error loading configs .*params.yaml`)
+	if _, err := testutils.WaitForString(ctx, reloadFailed, pr); err != nil {
+		t.Fatalf("timeout or error waiting for the config to be reloaded: %s", err)
+	}
+}
+
 func TestMutuallyExclusiveFlags(t *testing.T) {
 	testCases := []struct {
 		desc      string
@@ -925,6 +1046,7 @@ func TestSubcommandWiring(t *testing.T) {
 		{[]string{"invoke"}, "invoke"},
 		{[]string{"skills-generate"}, "skills-generate"},
 		{[]string{"serve"}, "serve"},
+		{[]string{"schema"}, "schema"},
 	}
 
 	for _, tc := range tests {
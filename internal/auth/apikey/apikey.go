@@ -0,0 +1,292 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/http"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const AuthServiceType string = "api-key"
+
+// reloadInterval is how often the keys file is checked for changes.
+var reloadInterval = 5 * time.Second
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Type string `yaml:"type" validate:"required"`
+	// Keys are the accepted API keys.
+	Keys []Key `yaml:"keys" validate:"dive"`
+	// KeysFile is a YAML file containing a list of keys. It is reloaded when
+	// it changes, so keys can be rotated without restarting Toolbox.
+	KeysFile string `yaml:"keysFile"`
+}
+
+// Key is an accepted API key and the claims it is granted.
+type Key struct {
+	// Id identifies the key in logs and traces.
+	Id string `yaml:"id" validate:"required"`
+	// Hash is the hex-encoded SHA-256 hash of the key, optionally prefixed
+	// with "sha256:".
+	Hash string `yaml:"hash" validate:"required"`
+	// Claims are returned for requests using the key.
+	Claims map[string]any `yaml:"claims"`
+	// Metadata is recorded in the audit log entry of requests using the key.
+	Metadata map[string]string `yaml:"metadata"`
+}
+
+// Returns the auth service type
+func (cfg Config) AuthServiceConfigType() string {
+	return AuthServiceType
+}
+
+// Initialize an API key auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	if len(cfg.Keys) == 0 && cfg.KeysFile == "" {
+		return nil, fmt.Errorf("at least one of keys or keysFile must be specified")
+	}
+	a := &AuthService{Config: cfg}
+	configKeys, err := decodeKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	a.configKeys = configKeys
+	if cfg.KeysFile != "" {
+		// a missing keys file only revokes its keys once it was loaded, at
+		// startup it is more likely a mistyped path
+		if _, err := os.Stat(cfg.KeysFile); err != nil {
+			return nil, fmt.Errorf("unable to read keys file: %w", err)
+		}
+		a.keysFile = &keysFile{path: cfg.KeysFile}
+		a.keysFile.reload()
+		if err := a.keysFile.current.Load().err; err != nil {
+			return nil, err
+		}
+		// the keys file is reloaded in the background until the auth service
+		// is no longer used, e.g. after the configuration was reloaded
+		stop := make(chan struct{})
+		go a.keysFile.watch(reloadInterval, stop)
+		runtime.AddCleanup(a, func(stop chan struct{}) { close(stop) }, stop)
+	}
+	return a, nil
+}
+
+var _ auth.AuthService = &AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Config
+	configKeys []hashedKey
+	keysFile   *keysFile
+}
+
+// hashedKey is a Key with its decoded hash.
+type hashedKey struct {
+	Key
+	sum []byte
+}
+
+// Returns the auth service type
+func (a *AuthService) AuthServiceType() string {
+	return AuthServiceType
+}
+
+func (a *AuthService) ToConfig() auth.AuthServiceConfig {
+	return a.Config
+}
+
+// Returns the name of the auth service
+func (a *AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the API key inside the `<name>_token` header and returns the
+// claims of the matching key
+func (a *AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	token := h.Get(a.Name + "_token")
+	if token == "" {
+		return nil, nil
+	}
+
+	keys := a.configKeys
+	if a.keysFile != nil {
+		set := a.keysFile.current.Load()
+		// reload errors are logged by the first request using the keys
+		if set.err != nil && set.logged.CompareAndSwap(false, true) {
+			if logger, err := util.LoggerFromContext(ctx); err == nil {
+				logger.WarnContext(ctx, fmt.Sprintf("unable to reload keys file of auth service %q, using its last valid keys: %s", a.Name, set.err))
+			}
+		}
+		keys = slices.Concat(a.configKeys, set.keys)
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	var match *hashedKey
+	// compare against every key, so the time taken doesn't depend on which
+	// key matched
+	for i := range keys {
+		if subtle.ConstantTimeCompare(sum[:], keys[i].sum) == 1 {
+			match = &keys[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("invalid API key")
+	}
+
+	a.audit(ctx, match.Key)
+	claims := maps.Clone(match.Claims)
+	if claims == nil {
+		claims = make(map[string]any)
+	}
+	return claims, nil
+}
+
+// audit records the use of a key, along with its metadata.
+func (a *AuthService) audit(ctx context.Context, k Key) {
+	attrs := []attribute.KeyValue{
+		attribute.String("auth.service", a.Name),
+		attribute.String("auth.api_key.id", k.Id),
+	}
+	args := []any{"authService", a.Name, "keyId", k.Id}
+	for name, v := range k.Metadata {
+		attrs = append(attrs, attribute.String("auth.api_key.metadata."+name, v))
+		args = append(args, "metadata."+name, v)
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+	if logger, err := util.LoggerFromContext(ctx); err == nil {
+		logger.InfoContext(ctx, "API key authenticated", args...)
+	}
+}
+
+// keySet is the content of the keys file as of its last reload.
+type keySet struct {
+	keys []hashedKey
+	// err is the error of the last reload, in which case keys are the last
+	// valid keys of the file
+	err    error
+	logged atomic.Bool
+}
+
+// keysFile holds the keys of a keys file, which is reloaded in the
+// background, so that requests never wait on it.
+type keysFile struct {
+	path    string
+	current atomic.Pointer[keySet]
+
+	// mod and size identify the version of the file read by the last reload
+	mod  time.Time
+	size int64
+}
+
+// watch reloads the keys file every interval until stop is closed.
+func (f *keysFile) watch(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			f.reload()
+		}
+	}
+}
+
+// reload reads the keys file again if it changed since it was last read. A
+// missing keys file has no keys. If the keys file can't be read, its last
+// valid keys are kept along with the error, which is only reported once per
+// change of the file.
+func (f *keysFile) reload() {
+	last := f.current.Load()
+	var lastKeys []hashedKey
+	if last != nil {
+		lastKeys = last.keys
+	}
+
+	info, err := os.Stat(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		f.mod, f.size = time.Time{}, 0
+		f.current.Store(&keySet{})
+		return
+	}
+	if err != nil {
+		// the file can't be checked for changes, so the error is only
+		// reported when it differs from the last one
+		err = fmt.Errorf("unable to read keys file: %w", err)
+		if last == nil || last.err == nil || last.err.Error() != err.Error() {
+			f.current.Store(&keySet{keys: lastKeys, err: err})
+		}
+		return
+	}
+	if last != nil && info.ModTime().Equal(f.mod) && info.Size() == f.size {
+		return
+	}
+
+	f.mod, f.size = info.ModTime(), info.Size()
+	keys, err := readKeysFile(f.path)
+	if err != nil {
+		f.current.Store(&keySet{keys: lastKeys, err: err})
+		return
+	}
+	f.current.Store(&keySet{keys: keys})
+}
+
+func readKeysFile(path string) ([]hashedKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read keys file: %w", err)
+	}
+	var keys []Key
+	if err := yaml.UnmarshalWithOptions(raw, &keys, yaml.Strict(), yaml.Validator(validator.New())); err != nil {
+		return nil, fmt.Errorf("unable to parse keys file: %w", err)
+	}
+	return decodeKeys(keys)
+}
+
+func decodeKeys(keys []Key) ([]hashedKey, error) {
+	hashed := make([]hashedKey, 0, len(keys))
+	for _, k := range keys {
+		sum, err := hex.DecodeString(strings.TrimPrefix(k.Hash, "sha256:"))
+		if err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf("key %q: hash must be a hex-encoded SHA-256 hash", k.Id)
+		}
+		hashed = append(hashed, hashedKey{Key: k, sum: sum})
+	}
+	return hashed, nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func header(key string) http.Header {
+	h := http.Header{}
+	h.Set("my-api-key_token", key)
+	return h
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	cfg := Config{
+		Name: "my-api-key",
+		Type: AuthServiceType,
+		Keys: []Key{
+			{Id: "ci", Hash: hash("ci-secret"), Claims: map[string]any{"sub": "ci", "groups": []any{"deploy"}}},
+			{Id: "batch", Hash: "sha256:" + hash("batch-secret"), Metadata: map[string]string{"owner": "data-team"}},
+		},
+	}
+	authService, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+
+	ctx := context.Background()
+	claims, err := authService.GetClaimsFromHeader(ctx, header("ci-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"sub": "ci", "groups": []any{"deploy"}}, claims); diff != "" {
+		t.Errorf("incorrect claims: diff %v", diff)
+	}
+
+	// keys without claims still authenticate the request
+	claims, err = authService.GetClaimsFromHeader(ctx, header("batch-secret"))
+	if err != nil || claims == nil {
+		t.Fatalf("expected empty claims, got %v, %v", claims, err)
+	}
+
+	if _, err := authService.GetClaimsFromHeader(ctx, header("wrong-secret")); err == nil {
+		t.Errorf("expected error for invalid key")
+	}
+	claims, err = authService.GetClaimsFromHeader(ctx, http.Header{})
+	if err != nil || claims != nil {
+		t.Errorf("expected no claims without header, got %v, %v", claims, err)
+	}
+}
+
+func TestKeysFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeys := func(key string, mod time.Time) {
+		t.Helper()
+		content := fmt.Sprintf("- id: rotated\n  hash: %s\n  claims:\n    sub: %s\n", hash(key), key)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write keys file: %v", err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatalf("unable to set keys file time: %v", err)
+		}
+	}
+	writeKeys("old-secret", time.Now().Add(-time.Minute))
+
+	authService, err := Config{Name: "my-api-key", Type: AuthServiceType, KeysFile: path}.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	// the keys file is reloaded by the test rather than in the background
+	reload := authService.(*AuthService).keysFile.reload
+	ctx := context.Background()
+	if _, err := authService.GetClaimsFromHeader(ctx, header("old-secret")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writeKeys("new-secret", time.Now())
+	reload()
+	claims, err := authService.GetClaimsFromHeader(ctx, header("new-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error after rotation: %v", err)
+	}
+	if claims["sub"] != "new-secret" {
+		t.Errorf("expected claims of the rotated key, got %v", claims)
+	}
+	if _, err := authService.GetClaimsFromHeader(ctx, header("old-secret")); err == nil {
+		t.Errorf("expected rotated out key to be rejected")
+	}
+
+	// emptying or deleting the keys file revokes its keys
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("unable to write keys file: %v", err)
+	}
+	reload()
+	if _, err := authService.GetClaimsFromHeader(ctx, header("new-secret")); err == nil {
+		t.Errorf("expected keys of an emptied keys file to be rejected")
+	}
+	writeKeys("new-secret", time.Now().Add(time.Minute))
+	reload()
+	if _, err := authService.GetClaimsFromHeader(ctx, header("new-secret")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("unable to remove keys file: %v", err)
+	}
+	reload()
+	if _, err := authService.GetClaimsFromHeader(ctx, header("new-secret")); err == nil {
+		t.Errorf("expected keys of a deleted keys file to be rejected")
+	}
+}
+
+func TestKeysFileWatch(t *testing.T) {
+	interval := reloadInterval
+	reloadInterval = 10 * time.Millisecond
+	defer func() { reloadInterval = interval }()
+
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("- id: old\n  hash: %s\n", hash("old-secret"))), 0o600); err != nil {
+		t.Fatalf("unable to write keys file: %v", err)
+	}
+	authService, err := Config{Name: "my-api-key", Type: AuthServiceType, KeysFile: path}.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("- id: new\n  hash: %s\n", hash("new-secret"))), 0o600); err != nil {
+		t.Fatalf("unable to write keys file: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("unable to set keys file time: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := authService.GetClaimsFromHeader(context.Background(), header("new-secret"))
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the keys file to be reloaded in the background: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestInvalidKeysFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	content := fmt.Sprintf("- id: valid\n  hash: %s\n", hash("my-secret"))
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write keys file: %v", err)
+	}
+	authService, err := Config{Name: "my-api-key", Type: AuthServiceType, KeysFile: path}.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	reload := authService.(*AuthService).keysFile.reload
+
+	var buf bytes.Buffer
+	logger, err := log.NewStdLogger(&buf, &buf, "info")
+	if err != nil {
+		t.Fatalf("unable to create logger: %v", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+	breakKeys := func(content string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write keys file: %v", err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatalf("unable to set keys file time: %v", err)
+		}
+	}
+
+	// the last valid keys are kept, and the error is logged once
+	breakKeys("- id: broken\n", time.Now().Add(time.Minute))
+	for range 3 {
+		reload()
+		if _, err := authService.GetClaimsFromHeader(ctx, header("my-secret")); err != nil {
+			t.Fatalf("expected last valid keys to be used, got %v", err)
+		}
+	}
+	if got := strings.Count(buf.String(), "unable to reload keys file"); got != 1 {
+		t.Fatalf("expected the error to be logged once, got %d times: %s", got, buf.String())
+	}
+
+	// another invalid version of the file is logged again
+	breakKeys("- id: still-broken\n", time.Now().Add(2*time.Minute))
+	reload()
+	if _, err := authService.GetClaimsFromHeader(ctx, header("my-secret")); err != nil {
+		t.Fatalf("expected last valid keys to be used, got %v", err)
+	}
+	if got := strings.Count(buf.String(), "unable to reload keys file"); got != 2 {
+		t.Fatalf("expected the error to be logged once per change, got %d times: %s", got, buf.String())
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  Config
+		err  string
+	}{
+		{desc: "no keys", cfg: Config{Name: "my-api-key"}, err: "at least one of keys or keysFile"},
+		{desc: "invalid hash", cfg: Config{Name: "my-api-key", Keys: []Key{{Id: "ci", Hash: "not-a-hash"}}}, err: `key "ci": hash must be`},
+		{desc: "missing keys file", cfg: Config{Name: "my-api-key", KeysFile: filepath.Join(t.TempDir(), "missing.yaml")}, err: "unable to read keys file"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
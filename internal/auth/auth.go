@@ -16,7 +16,12 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 // AuthServiceConfig is the interface for configuring authentication services.
@@ -32,3 +37,163 @@ type AuthService interface {
 	GetClaimsFromHeader(context.Context, http.Header) (map[string]any, error)
 	ToConfig() AuthServiceConfig
 }
+
+// MCPAuthServiceConfig is implemented by the configs of auth services that
+// can authorize requests to the MCP endpoint.
+type MCPAuthServiceConfig interface {
+	AuthServiceConfig
+	IsMcpEnabled() bool
+	// GetAuthorizationServer returns the authorization server advertised in
+	// the Protected Resource Metadata.
+	GetAuthorizationServer() string
+	GetScopesRequired() []string
+}
+
+// MCPAuthService is implemented by auth services that can authorize requests
+// to the MCP endpoint.
+type MCPAuthService interface {
+	AuthService
+	// ValidateMCPAuth validates the token in the Authorization header and
+	// returns its claims. Validation failures are returned as *MCPAuthError.
+	ValidateMCPAuth(ctx context.Context, h http.Header) (map[string]any, error)
+}
+
+// MCPAuthError represents an error during MCP authentication validation.
+type MCPAuthError struct {
+	Code           int
+	Message        string
+	ScopesRequired []string
+}
+
+func (e *MCPAuthError) Error() string { return e.Message }
+
+// FindMCPAuthService returns the auth service with MCP authorization enabled,
+// if any.
+func FindMCPAuthService(authServices map[string]AuthService) (MCPAuthService, bool) {
+	for _, a := range authServices {
+		cfg, ok := a.ToConfig().(MCPAuthServiceConfig)
+		if !ok || !cfg.IsMcpEnabled() {
+			continue
+		}
+		if mcpSvc, ok := a.(MCPAuthService); ok {
+			return mcpSvc, true
+		}
+	}
+	return nil, false
+}
+
+type contextKey string
+
+// mcpClaimsKey is the key used to store the claims of the MCP authorization
+// token within context
+const mcpClaimsKey contextKey = "mcpClaims"
+
+type mcpClaims struct {
+	authServiceName string
+	claims          map[string]any
+}
+
+// WithMCPClaims adds the claims of the token validated by the MCP-enabled
+// auth service into the context.
+func WithMCPClaims(ctx context.Context, authServiceName string, claims map[string]any) context.Context {
+	return context.WithValue(ctx, mcpClaimsKey, mcpClaims{authServiceName: authServiceName, claims: claims})
+}
+
+// MCPClaimsFromContext retrieves the name of the MCP-enabled auth service and
+// the claims of the token it validated, if any.
+func MCPClaimsFromContext(ctx context.Context) (string, map[string]any, bool) {
+	c, ok := ctx.Value(mcpClaimsKey).(mcpClaims)
+	if !ok {
+		return "", nil, false
+	}
+	return c.authServiceName, c.claims, true
+}
+
+// ClaimsFromRequest returns the claims of every auth service with a valid
+// token in the header, keyed by auth service name, along with the claims of
+// the MCP authorization token in ctx. Invalid tokens are skipped.
+func ClaimsFromRequest(ctx context.Context, authServices map[string]AuthService, h http.Header) map[string]map[string]any {
+	claimsFromAuth := make(map[string]map[string]any)
+	// if using stdio, header will be nil and auth will not be supported
+	if h != nil {
+		for _, aS := range authServices {
+			claims, err := aS.GetClaimsFromHeader(ctx, h)
+			if err != nil {
+				if logger, lErr := util.LoggerFromContext(ctx); lErr == nil {
+					// server errors mean the token couldn't be verified at
+					// all, rather than being invalid
+					var csErr *util.ClientServerError
+					if errors.As(err, &csErr) && csErr.Code >= http.StatusInternalServerError {
+						logger.WarnContext(ctx, fmt.Sprintf("unable to verify the token of auth service %q: %s", aS.GetName(), err))
+					} else {
+						logger.DebugContext(ctx, err.Error())
+					}
+				}
+				continue
+			}
+			if claims == nil {
+				// authService not present in header
+				continue
+			}
+			claimsFromAuth[aS.GetName()] = claims
+		}
+	}
+	if name, claims, ok := MCPClaimsFromContext(ctx); ok {
+		claimsFromAuth[name] = claims
+	}
+	return claimsFromAuth
+}
+
+// subjectTokensKey is the key used to store the verified tokens of the caller
+// within context
+const subjectTokensKey contextKey = "subjectTokens"
+
+// SubjectToken is a verified token of the caller, along with its claims. It
+// can be exchanged for downstream credentials on behalf of the caller.
+type SubjectToken struct {
+	Token  string
+	Claims map[string]any
+}
+
+// SubjectTokensFromRequest returns the raw tokens of the auth services in
+// claimsFromAuth, keyed by auth service name. Tokens are read from the
+// `<name>_token` header, or from the Authorization header for the MCP-enabled
+// auth service.
+func SubjectTokensFromRequest(ctx context.Context, claimsFromAuth map[string]map[string]any, h http.Header) map[string]SubjectToken {
+	tokens := make(map[string]SubjectToken)
+	if h == nil {
+		return tokens
+	}
+	mcpName, _, _ := MCPClaimsFromContext(ctx)
+	for name, claims := range claimsFromAuth {
+		token := h.Get(name + "_token")
+		if name == mcpName {
+			scheme, t, ok := strings.Cut(h.Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "bearer") {
+				continue
+			}
+			token = t
+		}
+		if token == "" {
+			continue
+		}
+		tokens[name] = SubjectToken{Token: token, Claims: claims}
+	}
+	return tokens
+}
+
+// WithSubjectTokens adds the verified tokens of the caller into the context.
+func WithSubjectTokens(ctx context.Context, tokens map[string]SubjectToken) context.Context {
+	return context.WithValue(ctx, subjectTokensKey, tokens)
+}
+
+// SubjectTokenFromContext retrieves the verified token of the caller for the
+// given auth service, if any.
+func SubjectTokenFromContext(ctx context.Context, authServiceName string) (SubjectToken, bool) {
+	tokens, ok := ctx.Value(subjectTokensKey).(map[string]SubjectToken)
+	if !ok {
+		return SubjectToken{}, false
+	}
+	t, ok := tokens[authServiceName]
+	return t, ok
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+)
+
+func TestMCPClaimsContext(t *testing.T) {
+	ctx := context.Background()
+	if _, _, ok := auth.MCPClaimsFromContext(ctx); ok {
+		t.Fatalf("expected no claims in empty context")
+	}
+
+	want := map[string]any{"sub": "test-user"}
+	ctx = auth.WithMCPClaims(ctx, "my-auth", want)
+	name, got, ok := auth.MCPClaimsFromContext(ctx)
+	if !ok {
+		t.Fatalf("expected claims in context")
+	}
+	if name != "my-auth" {
+		t.Errorf("auth service name = %q, want %q", name, "my-auth")
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect claims: diff %v", diff)
+	}
+}
+
+func TestSubjectTokens(t *testing.T) {
+	ctx := auth.WithMCPClaims(context.Background(), "my-mcp-auth", map[string]any{"sub": "mcp-user"})
+	h := http.Header{}
+	h.Set("Authorization", "Bearer mcp-token")
+	h.Set("my-auth_token", "header-token")
+	claimsFromAuth := map[string]map[string]any{
+		"my-mcp-auth": {"sub": "mcp-user"},
+		"my-auth":     {"sub": "header-user"},
+	}
+
+	ctx = auth.WithSubjectTokens(ctx, auth.SubjectTokensFromRequest(ctx, claimsFromAuth, h))
+	tcs := []struct {
+		name  string
+		token string
+		sub   string
+	}{
+		{name: "my-mcp-auth", token: "mcp-token", sub: "mcp-user"},
+		{name: "my-auth", token: "header-token", sub: "header-user"},
+	}
+	for _, tc := range tcs {
+		got, ok := auth.SubjectTokenFromContext(ctx, tc.name)
+		if !ok {
+			t.Fatalf("expected subject token for %q", tc.name)
+		}
+		if got.Token != tc.token || got.Claims["sub"] != tc.sub {
+			t.Errorf("subject token for %q = %+v", tc.name, got)
+		}
+	}
+	if _, ok := auth.SubjectTokenFromContext(ctx, "other-auth"); ok {
+		t.Errorf("expected no subject token for unverified auth service")
+	}
+}
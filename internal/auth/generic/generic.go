@@ -22,6 +22,8 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,16 +35,34 @@ import (
 const AuthServiceType string = "generic"
 
 // validate interface
-var _ auth.AuthServiceConfig = Config{}
+var _ auth.MCPAuthServiceConfig = Config{}
 
 // Auth service configuration
 type Config struct {
-	Name                string   `yaml:"name" validate:"required"`
-	Type                string   `yaml:"type" validate:"required"`
-	Audience            string   `yaml:"audience" validate:"required"`
+	Name     string `yaml:"name" validate:"required"`
+	Type     string `yaml:"type" validate:"required"`
+	Audience string `yaml:"audience" validate:"required_without=Audiences"`
+	// Audiences are accepted in addition to Audience.
+	Audiences           []string `yaml:"audiences"`
 	McpEnabled          bool     `yaml:"mcpEnabled"`
-	AuthorizationServer string   `yaml:"authorizationServer" validate:"required"`
+	AuthorizationServer string   `yaml:"authorizationServer" validate:"required_without_all=JwksUri JwksFile Jwks"`
 	ScopesRequired      []string `yaml:"scopesRequired"`
+	// Issuer, if set, must match the `iss` claim.
+	Issuer string `yaml:"issuer"`
+	// Algorithms restricts the accepted signing algorithms (e.g. RS256).
+	Algorithms []string `yaml:"algorithms"`
+	// Leeway is the clock skew allowed when validating `exp`, `nbf` and
+	// `iat`, e.g. "30s".
+	Leeway string `yaml:"leeway"`
+	// ValidateIssuedAt rejects tokens whose `iat` is in the future.
+	ValidateIssuedAt bool `yaml:"validateIssuedAt"`
+	// RequiredClaims must be present in the token.
+	RequiredClaims []string `yaml:"requiredClaims"`
+	// JwksUri, JwksFile and Jwks provide the JWKS directly instead of
+	// discovering it from the authorization server.
+	JwksUri  string `yaml:"jwksUri"`
+	JwksFile string `yaml:"jwksFile"`
+	Jwks     string `yaml:"jwks"`
 }
 
 // Returns the auth service type
@@ -50,27 +70,99 @@ func (cfg Config) AuthServiceConfigType() string {
 	return AuthServiceType
 }
 
+// IsMcpEnabled returns true if the auth service authorizes MCP requests
+func (cfg Config) IsMcpEnabled() bool {
+	return cfg.McpEnabled
+}
+
+// GetAuthorizationServer returns the authorization server of the auth service
+func (cfg Config) GetAuthorizationServer() string {
+	return cfg.AuthorizationServer
+}
+
+// GetScopesRequired returns the scopes required by the auth service
+func (cfg Config) GetScopesRequired() []string {
+	return cfg.ScopesRequired
+}
+
 // Initialize a generic auth service
 func (cfg Config) Initialize() (auth.AuthService, error) {
-	// Discover the JWKS URL from the OIDC configuration endpoint
-	jwksURL, err := discoverJWKSURL(cfg.AuthorizationServer)
-	if err != nil {
-		return nil, fmt.Errorf("failed to discover JWKS URL: %w", err)
+	if cfg.McpEnabled && cfg.AuthorizationServer == "" {
+		return nil, fmt.Errorf("authorizationServer is required when mcpEnabled is true")
 	}
 
-	// Create the keyfunc to fetch and cache the JWKS in the background
-	kf, err := keyfunc.NewDefault([]string{jwksURL})
+	var leeway time.Duration
+	if cfg.Leeway != "" {
+		var err error
+		leeway, err = time.ParseDuration(cfg.Leeway)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leeway %q: %w", cfg.Leeway, err)
+		}
+	}
+
+	kf, err := cfg.newKeyfunc()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create keyfunc from JWKS URL %s: %w", jwksURL, err)
+		return nil, err
 	}
 
 	a := &AuthService{
 		Config: cfg,
 		kf:     kf,
+		leeway: leeway,
 	}
 	return a, nil
 }
 
+// newKeyfunc creates the keyfunc verifying token signatures, from the
+// configured JWKS or the JWKS discovered from the authorization server
+func (cfg Config) newKeyfunc() (keyfunc.Keyfunc, error) {
+	var sources int
+	for _, set := range []bool{cfg.JwksUri != "", cfg.JwksFile != "", cfg.Jwks != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, fmt.Errorf("only one of jwksUri, jwksFile and jwks can be specified")
+	}
+
+	switch {
+	case cfg.Jwks != "":
+		kf, err := keyfunc.NewJWKSetJSON(json.RawMessage(cfg.Jwks))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create keyfunc from jwks: %w", err)
+		}
+		return kf, nil
+	case cfg.JwksFile != "":
+		raw, err := os.ReadFile(cfg.JwksFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		kf, err := keyfunc.NewJWKSetJSON(json.RawMessage(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create keyfunc from JWKS file %s: %w", cfg.JwksFile, err)
+		}
+		return kf, nil
+	}
+
+	jwksURL := cfg.JwksUri
+	if jwksURL == "" {
+		// Discover the JWKS URL from the OIDC configuration endpoint
+		var err error
+		jwksURL, err = discoverJWKSURL(cfg.AuthorizationServer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover JWKS URL: %w", err)
+		}
+	}
+
+	// Create the keyfunc to fetch and cache the JWKS in the background
+	kf, err := keyfunc.NewDefault([]string{jwksURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keyfunc from JWKS URL %s: %w", jwksURL, err)
+	}
+	return kf, nil
+}
+
 func discoverJWKSURL(AuthorizationServer string) (string, error) {
 	u, err := url.Parse(AuthorizationServer)
 	if err != nil {
@@ -140,12 +232,13 @@ func discoverJWKSURL(AuthorizationServer string) (string, error) {
 	return config.JWKSURI, nil
 }
 
-var _ auth.AuthService = AuthService{}
+var _ auth.MCPAuthService = AuthService{}
 
 // struct used to store auth service info
 type AuthService struct {
 	Config
-	kf keyfunc.Keyfunc
+	kf     keyfunc.Keyfunc
+	leeway time.Duration
 }
 
 // Returns the auth service type
@@ -162,6 +255,43 @@ func (a AuthService) GetName() string {
 	return a.Name
 }
 
+// parseToken verifies the signature of the token and validates its standard
+// claims
+func (a AuthService) parseToken(tokenString string) (*jwt.Token, error) {
+	opts := []jwt.ParserOption{jwt.WithLeeway(a.leeway)}
+	// the issued at claim is only validated if enabled, with the same leeway
+	if a.ValidateIssuedAt {
+		opts = append(opts, jwt.WithIssuedAt())
+	}
+	if a.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.Issuer))
+	}
+	if len(a.Algorithms) > 0 {
+		opts = append(opts, jwt.WithValidMethods(a.Algorithms))
+	}
+	return jwt.Parse(tokenString, a.kf.Keyfunc, opts...)
+}
+
+// validAudience checks that one of the token's audiences is accepted
+func (a AuthService) validAudience(aud []string) bool {
+	for _, audItem := range aud {
+		if (a.Audience != "" && audItem == a.Audience) || slices.Contains(a.Audiences, audItem) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingClaim returns the first required claim missing from the token
+func (a AuthService) missingClaim(claims jwt.MapClaims) (string, bool) {
+	for _, c := range a.RequiredClaims {
+		if _, ok := claims[c]; !ok {
+			return c, true
+		}
+	}
+	return "", false
+}
+
 // Verifies generic JWT access token inside the Authorization header
 func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
 	if a.McpEnabled {
@@ -174,7 +304,7 @@ func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (ma
 	}
 
 	// Parse and verify the token signature
-	token, err := jwt.Parse(tokenString, a.kf.Keyfunc)
+	token, err := a.parseToken(tokenString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse and verify JWT token: %w", err)
 	}
@@ -194,75 +324,62 @@ func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (ma
 		return nil, fmt.Errorf("could not parse audience from token: %w", err)
 	}
 
-	isAudValid := false
-	for _, audItem := range aud {
-		if audItem == a.Audience {
-			isAudValid = true
-			break
-		}
+	if !a.validAudience(aud) {
+		return nil, fmt.Errorf("audience validation failed: expected %s, got %v", a.Audience, aud)
 	}
 
-	if !isAudValid {
-		return nil, fmt.Errorf("audience validation failed: expected %s, got %v", a.Audience, aud)
+	if c, missing := a.missingClaim(claims); missing {
+		return nil, fmt.Errorf("missing required claim %q", c)
 	}
 
 	return claims, nil
 }
 
 // MCPAuthError represents an error during MCP authentication validation.
-type MCPAuthError struct {
-	Code           int
-	Message        string
-	ScopesRequired []string
-}
-
-func (e *MCPAuthError) Error() string { return e.Message }
+type MCPAuthError = auth.MCPAuthError
 
-// ValidateMCPAuth handles MCP auth token validation
-func (a AuthService) ValidateMCPAuth(ctx context.Context, h http.Header) error {
+// ValidateMCPAuth handles MCP auth token validation and returns the claims of
+// the validated token
+func (a AuthService) ValidateMCPAuth(ctx context.Context, h http.Header) (map[string]any, error) {
 	tokenString := h.Get("Authorization")
 	if tokenString == "" {
-		return &MCPAuthError{Code: http.StatusUnauthorized, Message: "missing access token", ScopesRequired: a.ScopesRequired}
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "missing access token", ScopesRequired: a.ScopesRequired}
 	}
 
 	headerParts := strings.Split(tokenString, " ")
 	if len(headerParts) != 2 || strings.ToLower(headerParts[0]) != "bearer" {
-		return &MCPAuthError{Code: http.StatusUnauthorized, Message: "authorization header must be in the format 'Bearer <token>'", ScopesRequired: a.ScopesRequired}
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "authorization header must be in the format 'Bearer <token>'", ScopesRequired: a.ScopesRequired}
 	}
 
-	token, err := jwt.Parse(headerParts[1], a.kf.Keyfunc)
+	token, err := a.parseToken(headerParts[1])
 	if err != nil || !token.Valid {
-		return &MCPAuthError{Code: http.StatusUnauthorized, Message: "invalid or expired token", ScopesRequired: a.ScopesRequired}
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "invalid or expired token", ScopesRequired: a.ScopesRequired}
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return &MCPAuthError{Code: http.StatusUnauthorized, Message: "invalid JWT claims format", ScopesRequired: a.ScopesRequired}
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "invalid JWT claims format", ScopesRequired: a.ScopesRequired}
 	}
 
 	// Validate audience
 	aud, err := claims.GetAudience()
 	if err != nil {
-		return &MCPAuthError{Code: http.StatusUnauthorized, Message: "could not parse audience from token", ScopesRequired: a.ScopesRequired}
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "could not parse audience from token", ScopesRequired: a.ScopesRequired}
 	}
 
-	isAudValid := false
-	for _, audItem := range aud {
-		if audItem == a.Audience {
-			isAudValid = true
-			break
-		}
+	if !a.validAudience(aud) {
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "audience validation failed", ScopesRequired: a.ScopesRequired}
 	}
 
-	if !isAudValid {
-		return &MCPAuthError{Code: http.StatusUnauthorized, Message: "audience validation failed", ScopesRequired: a.ScopesRequired}
+	if _, missing := a.missingClaim(claims); missing {
+		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "missing required claims", ScopesRequired: a.ScopesRequired}
 	}
 
 	// Check scopes
 	if len(a.ScopesRequired) > 0 {
 		scopeClaim, ok := claims["scope"].(string)
 		if !ok {
-			return &MCPAuthError{Code: http.StatusForbidden, Message: "insufficient scopes", ScopesRequired: a.ScopesRequired}
+			return nil, &MCPAuthError{Code: http.StatusForbidden, Message: "insufficient scopes", ScopesRequired: a.ScopesRequired}
 		}
 
 		tokenScopes := strings.Split(scopeClaim, " ")
@@ -273,10 +390,10 @@ func (a AuthService) ValidateMCPAuth(ctx context.Context, h http.Header) error {
 
 		for _, requiredScope := range a.ScopesRequired {
 			if !scopeMap[requiredScope] {
-				return &MCPAuthError{Code: http.StatusForbidden, Message: "insufficient scopes", ScopesRequired: a.ScopesRequired}
+				return nil, &MCPAuthError{Code: http.StatusForbidden, Message: "insufficient scopes", ScopesRequired: a.ScopesRequired}
 			}
 		}
 	}
 
-	return nil
+	return claims, nil
 }
@@ -206,3 +206,244 @@ func TestGetClaimsFromHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMCPAuth(t *testing.T) {
+	privateKey := generateRSAPrivateKey(t)
+	keyID := "test-key-id"
+	server := setupJWKSMockServer(t, privateKey, keyID)
+	defer server.Close()
+
+	cfg := Config{
+		Name:                "test-generic-auth",
+		Type:                "generic",
+		Audience:            "my-audience",
+		McpEnabled:          true,
+		AuthorizationServer: server.URL,
+		ScopesRequired:      []string{"read:files"},
+	}
+
+	authService, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	genericAuth, ok := authService.(*AuthService)
+	if !ok {
+		t.Fatalf("expected *AuthService, got %T", authService)
+	}
+
+	token := generateValidToken(t, privateKey, keyID, jwt.MapClaims{
+		"aud":   "my-audience",
+		"scope": "read:files",
+		"sub":   "test-user",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	claims, err := genericAuth.ValidateMCPAuth(context.Background(), header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub, ok := claims["sub"].(string); !ok || sub != "test-user" {
+		t.Errorf("expected sub=test-user, got %v", claims["sub"])
+	}
+
+	header.Set("Authorization", "Bearer invalid")
+	claims, err = genericAuth.ValidateMCPAuth(context.Background(), header)
+	if err == nil {
+		t.Fatalf("expected error for invalid token")
+	}
+	if claims != nil {
+		t.Errorf("expected nil claims on error, got %v", claims)
+	}
+}
+
+func TestStrictValidation(t *testing.T) {
+	privateKey := generateRSAPrivateKey(t)
+	keyID := "test-key-id"
+	jwk, err := jwkset.NewJWKFromKey(privateKey.Public(), jwkset.JWKOptions{Metadata: jwkset.JWKMetadataOptions{KID: keyID}})
+	if err != nil {
+		t.Fatalf("failed to create JWK: %v", err)
+	}
+	jwks, err := json.Marshal(map[string]any{"keys": []jwkset.JWKMarshal{jwk.Marshal()}})
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+
+	// the JWKS is provided inline, so no authorization server is needed
+	cfg := Config{
+		Name:             "test-generic-auth",
+		Type:             "generic",
+		Audiences:        []string{"aud-a", "aud-b"},
+		Issuer:           "https://issuer.example.com",
+		Algorithms:       []string{"RS256"},
+		Leeway:           "1m",
+		ValidateIssuedAt: true,
+		RequiredClaims:   []string{"email"},
+		Jwks:             string(jwks),
+	}
+	authService, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"aud":   "aud-b",
+			"iss":   "https://issuer.example.com",
+			"email": "jane@example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	tests := []struct {
+		name        string
+		claims      func() jwt.MapClaims
+		errContains string
+	}{
+		{name: "valid token", claims: valid},
+		{
+			name: "expired within leeway",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["exp"] = time.Now().Add(-30 * time.Second).Unix()
+				return c
+			},
+		},
+		{
+			name: "not yet valid",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["nbf"] = time.Now().Add(time.Hour).Unix()
+				return c
+			},
+			errContains: "token is not valid yet",
+		},
+		{
+			name: "issued within leeway",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["iat"] = time.Now().Add(30 * time.Second).Unix()
+				return c
+			},
+		},
+		{
+			name: "issued in the future",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["iat"] = time.Now().Add(time.Hour).Unix()
+				return c
+			},
+			errContains: "token used before issued",
+		},
+		{
+			name: "wrong issuer",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["iss"] = "https://other.example.com"
+				return c
+			},
+			errContains: "token has invalid issuer",
+		},
+		{
+			name: "wrong audience",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["aud"] = "aud-c"
+				return c
+			},
+			errContains: "audience validation failed",
+		},
+		{
+			name: "missing required claim",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				delete(c, "email")
+				return c
+			},
+			errContains: `missing required claim "email"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("test-generic-auth_token", generateValidToken(t, privateKey, keyID, tc.claims()))
+			claims, err := authService.GetClaimsFromHeader(context.Background(), header)
+			if tc.errContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if claims["email"] != "jane@example.com" {
+					t.Errorf("expected email claim, got %v", claims["email"])
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.errContains) {
+				t.Fatalf("expected error containing %q, got %v", tc.errContains, err)
+			}
+		})
+	}
+
+	// the issued at claim isn't validated unless enabled
+	cfg.ValidateIssuedAt = false
+	lenient, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	future := valid()
+	future["iat"] = time.Now().Add(time.Hour).Unix()
+	header := http.Header{}
+	header.Set("test-generic-auth_token", generateValidToken(t, privateKey, keyID, future))
+	if _, err := lenient.GetClaimsFromHeader(context.Background(), header); err != nil {
+		t.Errorf("unexpected error for iat in the future without validateIssuedAt: %v", err)
+	}
+
+	// tokens signed with an algorithm that isn't allowed are rejected
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, valid())
+	token.Header["kid"] = keyID
+	signed, err := token.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	header = http.Header{}
+	header.Set("test-generic-auth_token", signed)
+	if _, err := authService.GetClaimsFromHeader(context.Background(), header); err == nil {
+		t.Errorf("expected error for disallowed signing algorithm")
+	}
+}
+
+func TestInvalidJWKSConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		errContains string
+	}{
+		{
+			name:        "multiple JWKS sources",
+			cfg:         Config{Name: "a", Audience: "aud", JwksUri: "https://example.com/jwks", Jwks: `{"keys":[]}`},
+			errContains: "only one of jwksUri, jwksFile and jwks",
+		},
+		{
+			name:        "missing JWKS file",
+			cfg:         Config{Name: "a", Audience: "aud", JwksFile: "does-not-exist.json"},
+			errContains: "failed to read JWKS file",
+		},
+		{
+			name:        "mcp without authorization server",
+			cfg:         Config{Name: "a", Audience: "aud", McpEnabled: true, Jwks: `{"keys":[]}`},
+			errContains: "authorizationServer is required",
+		},
+		{
+			name:        "invalid leeway",
+			cfg:         Config{Name: "a", Audience: "aud", Leeway: "soon", Jwks: `{"keys":[]}`},
+			errContains: "invalid leeway",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil || !strings.Contains(err.Error(), tc.errContains) {
+				t.Fatalf("expected error containing %q, got %v", tc.errContains, err)
+			}
+		})
+	}
+}
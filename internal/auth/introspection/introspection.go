@@ -0,0 +1,272 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspection
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const AuthServiceType string = "oauth2-introspection"
+
+// validate interface
+var _ auth.MCPAuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Type string `yaml:"type" validate:"required"`
+	// IntrospectionEndpoint is the RFC 7662 token introspection endpoint.
+	IntrospectionEndpoint string `yaml:"introspectionEndpoint" validate:"required"`
+	// ClientId and ClientSecret authenticate Toolbox to the introspection
+	// endpoint with HTTP Basic authentication.
+	ClientId     string `yaml:"clientId"`
+	ClientSecret string `yaml:"clientSecret"`
+	// Audience, if set, must be one of the token's audiences.
+	Audience            string   `yaml:"audience"`
+	McpEnabled          bool     `yaml:"mcpEnabled"`
+	AuthorizationServer string   `yaml:"authorizationServer" validate:"required_if=McpEnabled true"`
+	ScopesRequired      []string `yaml:"scopesRequired"`
+}
+
+// Returns the auth service type
+func (cfg Config) AuthServiceConfigType() string {
+	return AuthServiceType
+}
+
+// IsMcpEnabled returns true if the auth service authorizes MCP requests
+func (cfg Config) IsMcpEnabled() bool {
+	return cfg.McpEnabled
+}
+
+// GetAuthorizationServer returns the authorization server of the auth service
+func (cfg Config) GetAuthorizationServer() string {
+	return cfg.AuthorizationServer
+}
+
+// GetScopesRequired returns the scopes required by the auth service
+func (cfg Config) GetScopesRequired() []string {
+	return cfg.ScopesRequired
+}
+
+// Initialize an introspection auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	u, err := url.Parse(cfg.IntrospectionEndpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid introspection endpoint %q", cfg.IntrospectionEndpoint)
+	}
+	a := &AuthService{
+		Config: cfg,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			// Prevent redirect loops or redirects to internal sites
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cache: make(map[string]cacheEntry),
+	}
+	return a, nil
+}
+
+var _ auth.MCPAuthService = &AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Config
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// cacheEntry is an introspection response cached until the token expires.
+type cacheEntry struct {
+	claims map[string]any
+	exp    time.Time
+}
+
+// Returns the auth service type
+func (a *AuthService) AuthServiceType() string {
+	return AuthServiceType
+}
+
+func (a *AuthService) ToConfig() auth.AuthServiceConfig {
+	return a.Config
+}
+
+// Returns the name of the auth service
+func (a *AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the opaque access token inside the `<name>_token` header
+func (a *AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	if a.McpEnabled {
+		return nil, nil
+	}
+
+	token := h.Get(a.Name + "_token")
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := a.introspect(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !a.validAudience(claims) {
+		return nil, fmt.Errorf("audience validation failed: expected %s, got %v", a.Audience, claims["aud"])
+	}
+	return claims, nil
+}
+
+// ValidateMCPAuth handles MCP auth token validation and returns the claims of
+// the validated token
+func (a *AuthService) ValidateMCPAuth(ctx context.Context, h http.Header) (map[string]any, error) {
+	tokenString := h.Get("Authorization")
+	if tokenString == "" {
+		return nil, &auth.MCPAuthError{Code: http.StatusUnauthorized, Message: "missing access token", ScopesRequired: a.ScopesRequired}
+	}
+
+	headerParts := strings.Split(tokenString, " ")
+	if len(headerParts) != 2 || strings.ToLower(headerParts[0]) != "bearer" {
+		return nil, &auth.MCPAuthError{Code: http.StatusUnauthorized, Message: "authorization header must be in the format 'Bearer <token>'", ScopesRequired: a.ScopesRequired}
+	}
+
+	claims, err := a.introspect(ctx, headerParts[1])
+	if err != nil {
+		// the token can't be verified while the introspection endpoint fails
+		var unavailable *util.ClientServerError
+		if errors.As(err, &unavailable) {
+			return nil, &auth.MCPAuthError{Code: unavailable.Code, Message: unavailable.Msg, ScopesRequired: a.ScopesRequired}
+		}
+		return nil, &auth.MCPAuthError{Code: http.StatusUnauthorized, Message: "invalid or expired token", ScopesRequired: a.ScopesRequired}
+	}
+	if !a.validAudience(claims) {
+		return nil, &auth.MCPAuthError{Code: http.StatusUnauthorized, Message: "audience validation failed", ScopesRequired: a.ScopesRequired}
+	}
+
+	// Check scopes
+	if len(a.ScopesRequired) > 0 {
+		scopeClaim, _ := claims["scope"].(string)
+		tokenScopes := strings.Fields(scopeClaim)
+		for _, requiredScope := range a.ScopesRequired {
+			if !slices.Contains(tokenScopes, requiredScope) {
+				return nil, &auth.MCPAuthError{Code: http.StatusForbidden, Message: "insufficient scopes", ScopesRequired: a.ScopesRequired}
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+// validAudience checks the `aud` claim, which can be a string or a list of
+// strings, against the configured audience.
+func (a *AuthService) validAudience(claims map[string]any) bool {
+	if a.Audience == "" {
+		return true
+	}
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == a.Audience
+	case []any:
+		return slices.Contains(aud, any(a.Audience))
+	}
+	return false
+}
+
+// introspect returns the introspection response of an active token, using
+// the cached response if the token was already introspected. Failures of the
+// introspection endpoint are returned as 503 errors, as they don't mean that
+// the token is invalid.
+func (a *AuthService) introspect(ctx context.Context, token string) (map[string]any, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	a.mu.Lock()
+	entry, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok && now.Before(entry.exp) {
+		return entry.claims, nil
+	}
+
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.IntrospectionEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, util.NewClientServerError("failed to create introspection request", http.StatusServiceUnavailable, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if a.ClientId != "" {
+		req.SetBasicAuth(url.QueryEscape(a.ClientId), url.QueryEscape(a.ClientSecret))
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, util.NewClientServerError("failed to introspect token", http.StatusServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.NewClientServerError(fmt.Sprintf("unexpected introspection status: %d", resp.StatusCode), http.StatusServiceUnavailable, nil)
+	}
+
+	// Limit read size to 1MB to prevent memory exhaustion
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, util.NewClientServerError("failed to read introspection response", http.StatusServiceUnavailable, err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(body, &claims); err != nil {
+		return nil, util.NewClientServerError("invalid introspection response", http.StatusServiceUnavailable, err)
+	}
+	if active, _ := claims["active"].(bool); !active {
+		return nil, fmt.Errorf("token is not active")
+	}
+
+	// tokens without an expiry are introspected on every request
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return claims, nil
+	}
+	expiry := time.Unix(int64(exp), 0)
+	if !now.Before(expiry) {
+		return nil, fmt.Errorf("token is expired")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for k, e := range a.cache {
+		if !now.Before(e.exp) {
+			delete(a.cache, k)
+		}
+	}
+	a.cache[key] = cacheEntry{claims: claims, exp: expiry}
+	return claims, nil
+}
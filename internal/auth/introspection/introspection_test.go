@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// setupIntrospectionServer returns a server introspecting the given tokens,
+// and the number of introspection requests it received.
+func setupIntrospectionServer(t *testing.T, tokens map[string]map[string]any) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if id, secret, ok := r.BasicAuth(); !ok || id != "my-client" || secret != "my-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// the endpoint fails for these tokens
+		switch r.PostForm.Get("token") {
+		case "unavailable-token":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case "malformed-token":
+			_, _ = w.Write([]byte("not json"))
+			return
+		}
+		resp, ok := tokens[r.PostForm.Get("token")]
+		if !ok {
+			resp = map[string]any{"active": false}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	return server, &calls
+}
+
+func TestIntrospection(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	server, calls := setupIntrospectionServer(t, map[string]map[string]any{
+		"valid-token":     {"active": true, "sub": "test-user", "aud": "my-audience", "scope": "mcp:tools openid", "exp": exp},
+		"no-scope-token":  {"active": true, "sub": "test-user", "aud": "my-audience", "exp": exp},
+		"other-aud-token": {"active": true, "sub": "test-user", "aud": []any{"other-audience"}, "scope": "mcp:tools", "exp": exp},
+	})
+	defer server.Close()
+
+	cfg := Config{
+		Name:                  "my-introspection",
+		Type:                  AuthServiceType,
+		IntrospectionEndpoint: server.URL,
+		ClientId:              "my-client",
+		ClientSecret:          "my-secret",
+		Audience:              "my-audience",
+		McpEnabled:            true,
+		AuthorizationServer:   "https://auth.example.com",
+		ScopesRequired:        []string{"mcp:tools"},
+	}
+	authService, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	mcpSvc, ok := authService.(auth.MCPAuthService)
+	if !ok {
+		t.Fatalf("expected an MCP auth service, got %T", authService)
+	}
+
+	ctx := context.Background()
+	tcs := []struct {
+		desc  string
+		token string
+		code  int
+	}{
+		{desc: "valid token", token: "valid-token"},
+		{desc: "inactive token", token: "unknown-token", code: http.StatusUnauthorized},
+		{desc: "wrong audience", token: "other-aud-token", code: http.StatusUnauthorized},
+		{desc: "insufficient scopes", token: "no-scope-token", code: http.StatusForbidden},
+		{desc: "endpoint unavailable", token: "unavailable-token", code: http.StatusServiceUnavailable},
+		{desc: "malformed response", token: "malformed-token", code: http.StatusServiceUnavailable},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("Authorization", "Bearer "+tc.token)
+			claims, err := mcpSvc.ValidateMCPAuth(ctx, h)
+			if tc.code == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if claims["sub"] != "test-user" {
+					t.Errorf("expected sub=test-user, got %v", claims["sub"])
+				}
+				return
+			}
+			var mcpErr *auth.MCPAuthError
+			if !errors.As(err, &mcpErr) || mcpErr.Code != tc.code {
+				t.Fatalf("expected MCP auth error with code %d, got %v", tc.code, err)
+			}
+		})
+	}
+
+	// the valid token was cached until its expiry
+	before := calls.Load()
+	h := http.Header{}
+	h.Set("Authorization", "Bearer valid-token")
+	if _, err := mcpSvc.ValidateMCPAuth(ctx, h); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if after := calls.Load(); after != before {
+		t.Errorf("expected cached introspection response, got %d new requests", after-before)
+	}
+
+	// tokens are only read from `<name>_token` when MCP auth is disabled
+	claims, err := mcpSvc.GetClaimsFromHeader(ctx, http.Header{"My-Introspection_token": {"valid-token"}})
+	if err != nil || claims != nil {
+		t.Errorf("expected no claims when mcpEnabled, got %v, %v", claims, err)
+	}
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	server, _ := setupIntrospectionServer(t, map[string]map[string]any{
+		"valid-token":   {"active": true, "sub": "test-user", "exp": time.Now().Add(time.Hour).Unix()},
+		"expired-token": {"active": true, "sub": "test-user", "exp": time.Now().Add(-time.Hour).Unix()},
+	})
+	defer server.Close()
+
+	cfg := Config{
+		Name:                  "my-introspection",
+		Type:                  AuthServiceType,
+		IntrospectionEndpoint: server.URL,
+		ClientId:              "my-client",
+		ClientSecret:          "my-secret",
+	}
+	authService, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+
+	ctx := context.Background()
+	h := http.Header{}
+	h.Set("my-introspection_token", "valid-token")
+	claims, err := authService.GetClaimsFromHeader(ctx, h)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims["sub"] != "test-user" {
+		t.Errorf("expected sub=test-user, got %v", claims["sub"])
+	}
+
+	h.Set("my-introspection_token", "expired-token")
+	if _, err := authService.GetClaimsFromHeader(ctx, h); err == nil {
+		t.Errorf("expected error for expired token")
+	}
+
+	claims, err = authService.GetClaimsFromHeader(ctx, http.Header{})
+	if err != nil || claims != nil {
+		t.Errorf("expected no claims without header, got %v, %v", claims, err)
+	}
+
+	// failures of the endpoint are server errors, not invalid tokens
+	h.Set("my-introspection_token", "unavailable-token")
+	_, err = authService.GetClaimsFromHeader(ctx, h)
+	var csErr *util.ClientServerError
+	if !errors.As(err, &csErr) || csErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected a 503 error for an unavailable endpoint, got %v", err)
+	}
+}
+
+func TestUnreachableEndpoint(t *testing.T) {
+	server, _ := setupIntrospectionServer(t, nil)
+	server.Close()
+
+	authService, err := Config{
+		Name:                  "my-introspection",
+		Type:                  AuthServiceType,
+		IntrospectionEndpoint: server.URL,
+		McpEnabled:            true,
+		AuthorizationServer:   "https://auth.example.com",
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	h := http.Header{}
+	h.Set("Authorization", "Bearer valid-token")
+	_, err = authService.(auth.MCPAuthService).ValidateMCPAuth(context.Background(), h)
+	var mcpErr *auth.MCPAuthError
+	if !errors.As(err, &mcpErr) || mcpErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected MCP auth error with code %d, got %v", http.StatusServiceUnavailable, err)
+	}
+}
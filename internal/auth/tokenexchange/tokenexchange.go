@@ -0,0 +1,255 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokenexchange implements the OAuth 2.0 Token Exchange (RFC 8693),
+// turning the verified token of the caller into a downstream credential, so
+// that sources run on behalf of the end user. The http source sends the
+// exchanged token upstream, and the postgres, mysql and mssql sources, with
+// their Cloud SQL and AlloyDB variants, connect to the database with it.
+// Sources with `useClientOAuth` forward the caller's token as is.
+package tokenexchange
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	GrantType        = "urn:ietf:params:oauth:grant-type:token-exchange"
+	AccessTokenType  = "urn:ietf:params:oauth:token-type:access_token"
+	defaultTokenType = AccessTokenType
+	// expiryMargin is subtracted from the lifetime of exchanged tokens, so
+	// that they aren't used right before they expire.
+	expiryMargin = 30 * time.Second
+)
+
+// Config configures the exchange of the caller's token for a downstream
+// credential.
+type Config struct {
+	// TokenEndpoint is the RFC 8693 token endpoint of the security token
+	// service.
+	TokenEndpoint string `yaml:"tokenEndpoint" validate:"required"`
+	// AuthService is the name of the auth service whose verified token is
+	// used as the subject token.
+	AuthService string `yaml:"authService" validate:"required"`
+	// ClientId and ClientSecret authenticate Toolbox to the token endpoint
+	// with HTTP Basic authentication.
+	ClientId     string `yaml:"clientId"`
+	ClientSecret string `yaml:"clientSecret"`
+	// Audience and Resource identify the downstream service the credential
+	// is requested for.
+	Audience           string   `yaml:"audience"`
+	Resource           string   `yaml:"resource"`
+	Scopes             []string `yaml:"scopes"`
+	SubjectTokenType   string   `yaml:"subjectTokenType"`
+	RequestedTokenType string   `yaml:"requestedTokenType"`
+}
+
+// Initialize creates an Exchanger from the config.
+func (cfg Config) Initialize() (*Exchanger, error) {
+	u, err := url.Parse(cfg.TokenEndpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid token exchange endpoint %q", cfg.TokenEndpoint)
+	}
+	if cfg.AuthService == "" {
+		return nil, fmt.Errorf("token exchange requires an authService")
+	}
+	if cfg.SubjectTokenType == "" {
+		cfg.SubjectTokenType = defaultTokenType
+	}
+	if cfg.RequestedTokenType == "" {
+		cfg.RequestedTokenType = defaultTokenType
+	}
+	return &Exchanger{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			// Prevent redirect loops or redirects to internal sites
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cache: make(map[string]cacheEntry),
+	}, nil
+}
+
+// Exchanger exchanges the verified tokens of callers for downstream
+// credentials, caching them per subject token until either expires.
+type Exchanger struct {
+	cfg    Config
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	token string
+	exp   time.Time
+}
+
+// tokenResponse is the response of the token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Error       string `json:"error"`
+	ErrorDesc   string `json:"error_description"`
+}
+
+// Token returns the downstream credential of the caller in ctx. It returns a
+// 401 error if the caller has no verified token for the configured auth
+// service.
+func (e *Exchanger) Token(ctx context.Context) (string, error) {
+	subject, ok := auth.SubjectTokenFromContext(ctx, e.cfg.AuthService)
+	if !ok {
+		return "", util.NewCallerError(
+			fmt.Sprintf("a verified token from auth service %q is required to act on behalf of the caller", e.cfg.AuthService),
+			http.StatusUnauthorized,
+			nil,
+		)
+	}
+
+	key := cacheKey(subject.Token)
+	now := time.Now()
+	e.mu.Lock()
+	entry, ok := e.cache[key]
+	e.mu.Unlock()
+	if ok && now.Before(entry.exp) {
+		return entry.token, nil
+	}
+
+	resp, err := e.exchange(ctx, subject.Token)
+	if err != nil {
+		return "", err
+	}
+	if resp.ExpiresIn <= 0 {
+		// credentials without a lifetime are exchanged on every request
+		return resp.AccessToken, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for k, c := range e.cache {
+		if !now.Before(c.exp) {
+			delete(e.cache, k)
+		}
+	}
+	exp := now.Add(time.Duration(resp.ExpiresIn)*time.Second - expiryMargin)
+	// the credential is never reused once the caller's token has expired
+	if subjectExp, ok := expiry(subject.Claims); ok && subjectExp.Before(exp) {
+		exp = subjectExp
+	}
+	e.cache[key] = cacheEntry{token: resp.AccessToken, exp: exp}
+	return resp.AccessToken, nil
+}
+
+// cacheKey identifies a subject token by its hash, so that a credential is
+// only reused for the token it was exchanged for.
+func cacheKey(subjectToken string) string {
+	sum := sha256.Sum256([]byte(subjectToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// expiry returns the time of the `exp` claim, if any.
+func expiry(claims map[string]any) (time.Time, bool) {
+	var exp int64
+	switch v := claims["exp"].(type) {
+	case float64:
+		exp = int64(v)
+	case int64:
+		exp = v
+	case int:
+		exp = int64(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		exp = n
+	default:
+		return time.Time{}, false
+	}
+	return time.Unix(exp, 0), true
+}
+
+func (e *Exchanger) exchange(ctx context.Context, subjectToken string) (*tokenResponse, error) {
+	form := url.Values{
+		"grant_type":           {GrantType},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {e.cfg.SubjectTokenType},
+		"requested_token_type": {e.cfg.RequestedTokenType},
+	}
+	if e.cfg.Audience != "" {
+		form.Set("audience", e.cfg.Audience)
+	}
+	if e.cfg.Resource != "" {
+		form.Set("resource", e.cfg.Resource)
+	}
+	if len(e.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(e.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if e.cfg.ClientId != "" {
+		req.SetBasicAuth(url.QueryEscape(e.cfg.ClientId), url.QueryEscape(e.cfg.ClientSecret))
+	}
+
+	httpResp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	// Limit read size to 1MB to prevent memory exhaustion
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	var resp tokenResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("invalid token exchange response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		// the caller's token was rejected by the security token service
+		if resp.Error == "invalid_grant" || resp.Error == "invalid_target" {
+			return nil, util.NewCallerError(
+				fmt.Sprintf("token exchange rejected: %s", resp.Error),
+				http.StatusForbidden,
+				nil,
+			)
+		}
+		return nil, fmt.Errorf("unexpected token exchange status: %d %s %s", httpResp.StatusCode, resp.Error, resp.ErrorDesc)
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response is missing the access token")
+	}
+	return &resp, nil
+}
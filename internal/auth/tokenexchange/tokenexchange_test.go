@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenexchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// setupTokenEndpoint returns a token endpoint exchanging subject tokens for
+// "downstream-<subject token>", and the number of requests it received.
+func setupTokenEndpoint(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if id, secret, ok := r.BasicAuth(); !ok || id != "my-client" || secret != "my-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_client"})
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f := r.PostForm
+		if f.Get("grant_type") != GrantType || f.Get("subject_token_type") != AccessTokenType || f.Get("audience") != "db" || f.Get("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_request"})
+			return
+		}
+		if f.Get("subject_token") == "revoked-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "downstream-" + f.Get("subject_token"),
+			"issued_token_type": AccessTokenType,
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		})
+	}))
+	return server, &calls
+}
+
+func withSubject(token, sub string, exp time.Time) context.Context {
+	return auth.WithSubjectTokens(context.Background(), map[string]auth.SubjectToken{
+		"my-auth": {Token: token, Claims: map[string]any{"sub": sub, "exp": float64(exp.Unix())}},
+	})
+}
+
+func TestToken(t *testing.T) {
+	server, calls := setupTokenEndpoint(t)
+	defer server.Close()
+
+	e, err := Config{
+		TokenEndpoint: server.URL,
+		AuthService:   "my-auth",
+		ClientId:      "my-client",
+		ClientSecret:  "my-secret",
+		Audience:      "db",
+		Scopes:        []string{"read", "write"},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize exchanger: %v", err)
+	}
+
+	hour := time.Now().Add(time.Hour)
+	token, err := e.Token(withSubject("alice-token", "alice", hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "downstream-alice-token" {
+		t.Errorf("token = %q, want %q", token, "downstream-alice-token")
+	}
+
+	// credentials are cached per subject token
+	if token, err := e.Token(withSubject("alice-token", "alice", hour)); err != nil || token != "downstream-alice-token" {
+		t.Errorf("expected cached credential, got %q, %v", token, err)
+	}
+	if token, err := e.Token(withSubject("alice-token-2", "alice", hour)); err != nil || token != "downstream-alice-token-2" {
+		t.Errorf("expected credential of the other token, got %q, %v", token, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 token exchanges, got %d", got)
+	}
+
+	// credentials aren't cached past the expiry of the subject token
+	for i := 0; i < 2; i++ {
+		if token, err := e.Token(withSubject("bob-token", "bob", time.Now().Add(-time.Second))); err != nil || token != "downstream-bob-token" {
+			t.Errorf("expected credential of other subject, got %q, %v", token, err)
+		}
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("expected 4 token exchanges, got %d", got)
+	}
+
+	var csErr *util.ClientServerError
+	_, err = e.Token(context.Background())
+	if !errors.As(err, &csErr) || csErr.Code != http.StatusUnauthorized || !csErr.Caller {
+		t.Errorf("expected 401 without a caller token, got %v", err)
+	}
+	_, err = e.Token(withSubject("revoked-token", "carol", hour))
+	if !errors.As(err, &csErr) || csErr.Code != http.StatusForbidden || !csErr.Caller {
+		t.Errorf("expected 403 for a rejected token, got %v", err)
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  Config
+		err  string
+	}{
+		{desc: "invalid endpoint", cfg: Config{TokenEndpoint: "not-a-url", AuthService: "my-auth"}, err: "invalid token exchange endpoint"},
+		{desc: "no auth service", cfg: Config{TokenEndpoint: "https://sts.example.com/token"}, err: "requires an authService"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
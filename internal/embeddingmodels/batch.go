@@ -0,0 +1,167 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchMaxSize = 64
+	defaultBatchWindow  = 10 * time.Millisecond
+)
+
+// BatchOptions configures the batching of concurrent embedding requests.
+type BatchOptions struct {
+	// MaxSize is the maximum number of texts sent in one upstream call.
+	MaxSize int `yaml:"maxSize"`
+	// Window is how long a request waits for other requests to join its
+	// batch, e.g. "20ms".
+	Window string `yaml:"window"`
+}
+
+var _ EmbeddingModelConfig = BatchedConfig{}
+
+// BatchedConfig wraps the config of an embedding model that has `batch`, so
+// that it is supported by every embedding model type.
+type BatchedConfig struct {
+	EmbeddingModelConfig
+	Batch BatchOptions
+}
+
+// Initialize initializes the wrapped embedding model.
+func (c BatchedConfig) Initialize(ctx context.Context) (EmbeddingModel, error) {
+	maxSize := c.Batch.MaxSize
+	if maxSize == 0 {
+		maxSize = defaultBatchMaxSize
+	}
+	if maxSize < 0 {
+		return nil, fmt.Errorf("batch maxSize must be positive")
+	}
+	window := defaultBatchWindow
+	if c.Batch.Window != "" {
+		var err error
+		window, err = time.ParseDuration(c.Batch.Window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid batch window %q: %w", c.Batch.Window, err)
+		}
+		if window <= 0 {
+			return nil, fmt.Errorf("batch window must be positive")
+		}
+	}
+
+	m, err := c.EmbeddingModelConfig.Initialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &batchedModel{EmbeddingModel: m, cfg: c, maxSize: maxSize, window: window}, nil
+}
+
+// batchedModel coalesces the concurrent requests to the wrapped model into a
+// single upstream call. A batch is sent once it is full, or once the window
+// of its first request has elapsed.
+type batchedModel struct {
+	EmbeddingModel
+	cfg     BatchedConfig
+	maxSize int
+	window  time.Duration
+
+	mu      sync.Mutex
+	pending *batch
+}
+
+// batch holds the texts of the requests waiting for the same upstream call.
+type batch struct {
+	// ctx is the context of the first request, without its cancellation, as
+	// the call is shared by every request of the batch
+	ctx        context.Context
+	texts      []string
+	done       chan struct{}
+	embeddings [][]float32
+	err        error
+}
+
+func (m *batchedModel) ToConfig() EmbeddingModelConfig {
+	return BatchedConfig{EmbeddingModelConfig: m.EmbeddingModel.ToConfig(), Batch: m.cfg.Batch}
+}
+
+func (m *batchedModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
+	if len(parameters) == 0 {
+		return [][]float32{}, nil
+	}
+	// large requests are a batch on their own
+	if len(parameters) >= m.maxSize {
+		return m.EmbeddingModel.EmbedParameters(ctx, parameters)
+	}
+
+	m.mu.Lock()
+	b := m.pending
+	if b != nil && len(b.texts)+len(parameters) > m.maxSize {
+		m.pending = nil
+		go m.send(b)
+		b = nil
+	}
+	if b == nil {
+		b = &batch{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		m.pending = b
+		time.AfterFunc(m.window, func() { m.flush(b) })
+	}
+	offset := len(b.texts)
+	b.texts = append(b.texts, parameters...)
+	if len(b.texts) == m.maxSize {
+		m.pending = nil
+		go m.send(b)
+	}
+	m.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.embeddings[offset : offset+len(parameters)], nil
+}
+
+// flush sends the batch, unless it was already sent for being full.
+func (m *batchedModel) flush(b *batch) {
+	m.mu.Lock()
+	if m.pending != b {
+		m.mu.Unlock()
+		return
+	}
+	m.pending = nil
+	m.mu.Unlock()
+	m.send(b)
+}
+
+func (m *batchedModel) send(b *batch) {
+	defer close(b.done)
+	embeddings, err := m.EmbeddingModel.EmbedParameters(b.ctx, b.texts)
+	if err != nil {
+		b.err = err
+		return
+	}
+	if len(embeddings) != len(b.texts) {
+		b.err = fmt.Errorf("model returned %d embeddings for %d inputs", len(embeddings), len(b.texts))
+		return
+	}
+	b.embeddings = embeddings
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+)
+
+func TestBatchedModel(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeModel{}
+	m, err := embeddingmodels.BatchedConfig{
+		EmbeddingModelConfig: fakeConfig{model: fake},
+		Batch:                embeddingmodels.BatchOptions{MaxSize: 4, Window: "50ms"},
+	}.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	// 3 concurrent requests of 1 text are coalesced into a single call
+	requests := []string{"a", "bb", "ccc"}
+	got := make([][][]float32, len(requests))
+	var wg sync.WaitGroup
+	for i, text := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e, err := m.EmbedParameters(ctx, []string{text})
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			got[i] = e
+		}()
+	}
+	wg.Wait()
+
+	want := [][][]float32{{{1}}, {{2}}, {{3}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect embeddings: diff %v", diff)
+	}
+	calls := fake.getCalls()
+	if len(calls) != 1 || len(calls[0]) != 3 {
+		t.Fatalf("expected a single call with 3 texts, got %v", calls)
+	}
+}
+
+func TestBatchedModelMaxSize(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeModel{}
+	m, err := embeddingmodels.BatchedConfig{
+		EmbeddingModelConfig: fakeConfig{model: fake},
+		// the window is long enough for the test to time out if full
+		// batches were not sent right away
+		Batch: embeddingmodels.BatchOptions{MaxSize: 2, Window: "1h"},
+	}.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, text := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := m.EmbedParameters(ctx, []string{text}); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// requests as large as a batch are sent on their own
+	got, err := m.EmbedParameters(ctx, []string{"a", "bb", "ccc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float32{{1}, {2}, {3}}, got); diff != "" {
+		t.Fatalf("incorrect embeddings: diff %v", diff)
+	}
+	if calls := fake.getCalls(); len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %v", calls)
+	}
+}
+
+func TestBatchedModelContextCanceled(t *testing.T) {
+	m, err := embeddingmodels.BatchedConfig{
+		EmbeddingModelConfig: fakeConfig{model: &fakeModel{}},
+		Batch:                embeddingmodels.BatchOptions{Window: "1h"},
+	}.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.EmbedParameters(ctx, []string{"a"}); err != context.Canceled {
+		t.Fatalf("unexpected error: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFailInitializeBatchedModel(t *testing.T) {
+	tcs := []struct {
+		desc  string
+		batch embeddingmodels.BatchOptions
+		err   string
+	}{
+		{
+			desc:  "negative max size",
+			batch: embeddingmodels.BatchOptions{MaxSize: -1},
+			err:   "batch maxSize must be positive",
+		},
+		{
+			desc:  "invalid window",
+			batch: embeddingmodels.BatchOptions{Window: "-1s"},
+			err:   "batch window must be positive",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := embeddingmodels.BatchedConfig{EmbeddingModelConfig: fakeConfig{model: &fakeModel{}}, Batch: tc.batch}.Initialize(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultCacheSize = 1000
+	defaultCacheTTL  = time.Hour
+)
+
+// CacheOptions configures the embedding cache of a model.
+type CacheOptions struct {
+	// Size is the maximum number of embeddings held by the cache.
+	Size int `yaml:"size"`
+	// TTL is how long an embedding is kept, e.g. "30m".
+	TTL string `yaml:"ttl"`
+}
+
+var _ EmbeddingModelConfig = CachedConfig{}
+
+// CachedConfig wraps the config of an embedding model that has `cache`, so
+// that it is supported by every embedding model type.
+type CachedConfig struct {
+	EmbeddingModelConfig
+	Name  string
+	Cache CacheOptions
+}
+
+// Initialize initializes the wrapped embedding model.
+func (c CachedConfig) Initialize(ctx context.Context) (EmbeddingModel, error) {
+	size := c.Cache.Size
+	if size == 0 {
+		size = defaultCacheSize
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("cache size must be positive")
+	}
+	ttl := defaultCacheTTL
+	if c.Cache.TTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(c.Cache.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache ttl %q: %w", c.Cache.TTL, err)
+		}
+		if ttl <= 0 {
+			return nil, fmt.Errorf("cache ttl must be positive")
+		}
+	}
+
+	m, err := c.EmbeddingModelConfig.Initialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// metrics are only recorded when the server provides an instrumentation
+	instrumentation, _ := util.InstrumentationFromContext(ctx)
+	return &cachedModel{
+		EmbeddingModel:  m,
+		cfg:             c,
+		size:            size,
+		ttl:             ttl,
+		instrumentation: instrumentation,
+		ll:              list.New(),
+		items:           make(map[string]*list.Element),
+	}, nil
+}
+
+// cachedModel returns the embeddings of texts embedded recently from a
+// size-bounded, expiring cache, and embeds the other texts with the wrapped
+// model.
+type cachedModel struct {
+	EmbeddingModel
+	cfg             CachedConfig
+	size            int
+	ttl             time.Duration
+	instrumentation *telemetry.Instrumentation
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key       string
+	embedding []float32
+	expiresAt time.Time
+}
+
+func (m *cachedModel) ToConfig() EmbeddingModelConfig {
+	return CachedConfig{EmbeddingModelConfig: m.EmbeddingModel.ToConfig(), Name: m.cfg.Name, Cache: m.cfg.Cache}
+}
+
+func (m *cachedModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
+	out := make([][]float32, len(parameters))
+	// indexes of each missing text, so that duplicates are embedded once
+	missing := make(map[string][]int)
+	var texts []string
+	for i, p := range parameters {
+		key := m.key(p)
+		if e, ok := m.get(key); ok {
+			out[i] = e
+			continue
+		}
+		if _, ok := missing[key]; !ok {
+			texts = append(texts, p)
+		}
+		missing[key] = append(missing[key], i)
+	}
+	m.record(ctx, len(parameters)-len(texts), len(texts))
+	if len(texts) == 0 {
+		return out, nil
+	}
+
+	embeddings, err := m.EmbeddingModel.EmbedParameters(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("model returned %d embeddings for %d inputs", len(embeddings), len(texts))
+	}
+	for i, text := range texts {
+		key := m.key(text)
+		m.set(key, embeddings[i])
+		for _, idx := range missing[key] {
+			out[idx] = embeddings[i]
+		}
+	}
+	return out, nil
+}
+
+// key identifies a text of the model, without keeping the text in memory.
+func (m *cachedModel) key(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return m.cfg.Name + "/" + hex.EncodeToString(sum[:])
+}
+
+func (m *cachedModel) get(key string) ([]float32, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*cacheEntry)
+	if time.Now().After(e.expiresAt) {
+		m.ll.Remove(el)
+		delete(m.items, key)
+		return nil, false
+	}
+	m.ll.MoveToFront(el)
+	return e.embedding, true
+}
+
+func (m *cachedModel) set(key string, embedding []float32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expiresAt := time.Now().Add(m.ttl)
+	if el, ok := m.items[key]; ok {
+		e := el.Value.(*cacheEntry)
+		e.embedding, e.expiresAt = embedding, expiresAt
+		m.ll.MoveToFront(el)
+		return
+	}
+	m.items[key] = m.ll.PushFront(&cacheEntry{key: key, embedding: embedding, expiresAt: expiresAt})
+	if m.ll.Len() > m.size {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// record adds the cache hits and misses to the metrics.
+func (m *cachedModel) record(ctx context.Context, hits, misses int) {
+	if m.instrumentation == nil {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("embedding_model.name", m.cfg.Name))
+	if hits > 0 {
+		m.instrumentation.EmbeddingCacheHits.Add(ctx, int64(hits), attrs)
+	}
+	if misses > 0 {
+		m.instrumentation.EmbeddingCacheMisses.Add(ctx, int64(misses), attrs)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// fakeModel embeds each text as a vector holding its length, and records
+// the texts of each call.
+type fakeModel struct {
+	mu    sync.Mutex
+	calls [][]string
+	delay time.Duration
+}
+
+func (m *fakeModel) EmbeddingModelType() string { return "fake" }
+
+func (m *fakeModel) ToConfig() embeddingmodels.EmbeddingModelConfig { return fakeConfig{model: m} }
+
+func (m *fakeModel) EmbedParameters(_ context.Context, texts []string) ([][]float32, error) {
+	time.Sleep(m.delay)
+	m.mu.Lock()
+	m.calls = append(m.calls, texts)
+	m.mu.Unlock()
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = []float32{float32(len(text))}
+	}
+	return out, nil
+}
+
+func (m *fakeModel) getCalls() [][]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+type fakeConfig struct {
+	model *fakeModel
+}
+
+func (c fakeConfig) EmbeddingModelConfigType() string { return "fake" }
+
+func (c fakeConfig) Initialize(context.Context) (embeddingmodels.EmbeddingModel, error) {
+	return c.model, nil
+}
+
+func TestCachedModel(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeModel{}
+	cfg := embeddingmodels.CachedConfig{
+		EmbeddingModelConfig: fakeConfig{model: fake},
+		Name:                 "my-model",
+		Cache:                embeddingmodels.CacheOptions{Size: 2},
+	}
+	m, err := cfg.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	got, err := m.EmbedParameters(ctx, []string{"a", "bb", "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float32{{1}, {2}, {1}}, got); diff != "" {
+		t.Fatalf("incorrect embeddings: diff %v", diff)
+	}
+	got, err = m.EmbedParameters(ctx, []string{"bb", "ccc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float32{{2}, {3}}, got); diff != "" {
+		t.Fatalf("incorrect embeddings: diff %v", diff)
+	}
+	// "a" was evicted, as the cache holds 2 embeddings
+	if _, err := m.EmbedParameters(ctx, []string{"a", "ccc"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := [][]string{{"a", "bb"}, {"ccc"}, {"a"}}
+	if diff := cmp.Diff(want, fake.getCalls()); diff != "" {
+		t.Fatalf("incorrect upstream calls: diff %v", diff)
+	}
+	if got := m.ToConfig(); !cmp.Equal(got, cfg, cmp.AllowUnexported(fakeConfig{}, fakeModel{}), cmp.Comparer(func(a, b *fakeModel) bool { return a == b })) {
+		t.Fatalf("incorrect config: %v", got)
+	}
+}
+
+func TestCachedModelTTL(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeModel{}
+	m, err := embeddingmodels.CachedConfig{
+		EmbeddingModelConfig: fakeConfig{model: fake},
+		Name:                 "my-model",
+		Cache:                embeddingmodels.CacheOptions{TTL: "10ms"},
+	}.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	for range 2 {
+		if _, err := m.EmbedParameters(ctx, []string{"a"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got := len(fake.getCalls()); got != 2 {
+		t.Fatalf("expected the expired embedding to be embedded again, got %d calls", got)
+	}
+}
+
+func TestCachedModelMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() { otel.SetMeterProvider(prev) })
+
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation("0.0.0")
+	if err != nil {
+		t.Fatalf("unable to create instrumentation: %s", err)
+	}
+	ctx := util.WithInstrumentation(context.Background(), instrumentation)
+	m, err := embeddingmodels.CachedConfig{
+		EmbeddingModelConfig: fakeConfig{model: &fakeModel{}},
+		Name:                 "my-model",
+	}.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	for _, texts := range [][]string{{"a", "b"}, {"a", "b", "c"}} {
+		if _, err := m.EmbedParameters(ctx, texts); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("unable to collect metrics: %s", err)
+	}
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, mt := range sm.Metrics {
+			if !strings.HasPrefix(mt.Name, "toolbox.embedding.cache.") {
+				continue
+			}
+			for _, dp := range mt.Data.(metricdata.Sum[int64]).DataPoints {
+				if v, _ := dp.Attributes.Value("embedding_model.name"); v.AsString() != "my-model" {
+					t.Fatalf("unexpected attributes: %v", dp.Attributes)
+				}
+				got[mt.Name] += dp.Value
+			}
+		}
+	}
+	want := map[string]int64{"toolbox.embedding.cache.hits": 2, "toolbox.embedding.cache.misses": 3}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect metrics: diff %v", diff)
+	}
+}
+
+func TestFailInitializeCachedModel(t *testing.T) {
+	tcs := []struct {
+		desc  string
+		cache embeddingmodels.CacheOptions
+		err   string
+	}{
+		{
+			desc:  "negative size",
+			cache: embeddingmodels.CacheOptions{Size: -1},
+			err:   "cache size must be positive",
+		},
+		{
+			desc:  "invalid ttl",
+			cache: embeddingmodels.CacheOptions{TTL: "soon"},
+			err:   `invalid cache ttl "soon": time: invalid duration "soon"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := embeddingmodels.CachedConfig{EmbeddingModelConfig: fakeConfig{model: &fakeModel{}}, Cache: tc.cache}.Initialize(context.Background())
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -34,8 +34,11 @@ type EmbeddingModel interface {
 
 type VectorFormatter func(vectorFloats []float32) any
 
-// FormatVectorForPgvector converts a slice of floats into a PostgreSQL vector literal string: '[x, y, z]'
-func FormatVectorForPgvector(vectorFloats []float32) any {
+// FormatVectorAsText converts a slice of floats into the text form of a
+// vector: '[x, y, z]'. It is accepted as is by pgvector, OceanBase and Dgraph,
+// and by the vector functions of other databases, such as `STRING_TO_VECTOR`
+// in MySQL or `VEC_FROM_TEXT` in TiDB.
+func FormatVectorAsText(vectorFloats []float32) any {
 	if len(vectorFloats) == 0 {
 		return "[]"
 	}
@@ -56,4 +59,23 @@ func FormatVectorForPgvector(vectorFloats []float32) any {
 	return b.String()
 }
 
-var _ VectorFormatter = FormatVectorForPgvector
+// FormatVectorAsFloatList converts a slice of floats into a []float64, for
+// sources taking vectors as lists of numbers, such as BigQuery, Elasticsearch
+// and MongoDB. Each float keeps its shortest decimal representation (0.1 stays
+// 0.1 rather than 0.10000000149011612).
+func FormatVectorAsFloatList(vectorFloats []float32) any {
+	out := make([]float64, len(vectorFloats))
+	for i, f := range vectorFloats {
+		v, err := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+		if err != nil {
+			v = float64(f)
+		}
+		out[i] = v
+	}
+	return out
+}
+
+var (
+	_ VectorFormatter = FormatVectorAsText
+	_ VectorFormatter = FormatVectorAsFloatList
+)
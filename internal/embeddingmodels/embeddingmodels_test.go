@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddingmodels_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+)
+
+func TestVectorFormatters(t *testing.T) {
+	vector := []float32{0.1, -2, 3.5e-7}
+	tcs := []struct {
+		desc      string
+		formatter embeddingmodels.VectorFormatter
+		in        []float32
+		want      any
+	}{
+		{
+			desc:      "text",
+			formatter: embeddingmodels.FormatVectorAsText,
+			in:        vector,
+			want:      "[0.1, -2, 3.5e-07]",
+		},
+		{
+			desc:      "text empty",
+			formatter: embeddingmodels.FormatVectorAsText,
+			in:        []float32{},
+			want:      "[]",
+		},
+		{
+			desc:      "float list",
+			formatter: embeddingmodels.FormatVectorAsFloatList,
+			in:        vector,
+			want:      []float64{0.1, -2, 3.5e-7},
+		},
+		{
+			desc:      "float list empty",
+			formatter: embeddingmodels.FormatVectorAsFloatList,
+			in:        []float32{},
+			want:      []float64{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.formatter(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect formatted vector: diff %v", diff)
+			}
+		})
+	}
+}
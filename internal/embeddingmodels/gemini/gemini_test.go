@@ -90,6 +90,35 @@ func TestParseFromYamlGemini(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with cache and batch",
+			in: `
+            kind: embeddingModel
+            name: cached-gemini
+            type: gemini
+            model: gemini-embedding-001
+            cache:
+              size: 500
+              ttl: 30m
+            batch:
+              maxSize: 32
+              window: 20ms
+            `,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"cached-gemini": embeddingmodels.CachedConfig{
+					EmbeddingModelConfig: embeddingmodels.BatchedConfig{
+						EmbeddingModelConfig: gemini.Config{
+							Name:  "cached-gemini",
+							Type:  gemini.EmbeddingModelType,
+							Model: "gemini-embedding-001",
+						},
+						Batch: embeddingmodels.BatchOptions{MaxSize: 32, Window: "20ms"},
+					},
+					Name:  "cached-gemini",
+					Cache: embeddingmodels.CacheOptions{Size: 500, TTL: "30m"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -131,6 +160,18 @@ func TestFailParseFromYamlGemini(t *testing.T) {
             `,
 			err: "error unmarshaling embeddingModel: unable to parse as \"bad-field\": [1:1] unknown field \"invalid_param\"\n>  1 | invalid_param: true\n       ^\n   2 | model: gemini-embedding-001\n   3 | name: bad-field\n   4 | type: gemini",
 		},
+		{
+			desc: "unknown cache field",
+			in: `
+            kind: embeddingModel
+            name: bad-cache
+            type: gemini
+            model: gemini-embedding-001
+            cache:
+              entries: 10
+            `,
+			err: "error unmarshaling embeddingModel: unable to parse cache of embedding model \"bad-cache\": [1:1] unknown field \"entries\"\n>  1 | entries: 10\n       ^\n",
+		},
 		{
 			desc: "missing both Vertex and Google AI credentials",
 			in: `
@@ -0,0 +1,269 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const EmbeddingModelType string = "openai"
+
+const (
+	defaultBaseUrl    = "https://api.openai.com/v1"
+	defaultTimeout    = "30s"
+	defaultBatchSize  = 128
+	defaultMaxRetries = 3
+	// initialRetryDelay is doubled after each failed attempt, unless the
+	// server sets the Retry-After header
+	initialRetryDelay = 500 * time.Millisecond
+)
+
+// validate interface
+var _ embeddingmodels.EmbeddingModelConfig = Config{}
+
+// Config configures an embedding model served through the OpenAI
+// `/v1/embeddings` API, such as OpenAI, Azure OpenAI, Ollama or vLLM.
+type Config struct {
+	Name  string `yaml:"name" validate:"required"`
+	Type  string `yaml:"type" validate:"required"`
+	Model string `yaml:"model" validate:"required"`
+	// BaseUrl is the URL the `/embeddings` path is appended to. Defaults to
+	// the OpenAI API.
+	BaseUrl string `yaml:"baseUrl"`
+	ApiKey  string `yaml:"apiKey"`
+	// Dimension is sent as the `dimensions` of the request, if set.
+	Dimension int32             `yaml:"dimension" validate:"gte=0"`
+	Headers   map[string]string `yaml:"headers"`
+	// BatchSize is the maximum number of inputs sent in a single request.
+	BatchSize int `yaml:"batchSize" validate:"gte=0"`
+	// MaxRetries is the number of retries of requests failing with a
+	// network error, a 429 or a 5xx status. Requests aren't retried if the
+	// server asks to wait longer than the timeout.
+	MaxRetries *int   `yaml:"maxRetries" validate:"omitempty,gte=0"`
+	Timeout    string `yaml:"timeout"`
+}
+
+// Returns the embedding model type
+func (cfg Config) EmbeddingModelConfigType() string {
+	return EmbeddingModelType
+}
+
+// Initialize an OpenAI-compatible embedding model
+func (cfg Config) Initialize(ctx context.Context) (embeddingmodels.EmbeddingModel, error) {
+	baseUrl := cfg.BaseUrl
+	apiKey := cfg.ApiKey
+	if baseUrl == "" {
+		baseUrl = defaultBaseUrl
+		// only send the OpenAI key to the OpenAI API
+		if apiKey == "" {
+			apiKey = os.Getenv("OPENAI_API_KEY")
+		}
+	}
+	u, err := url.Parse(baseUrl)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid baseUrl %q for embedding model %q", baseUrl, cfg.Name)
+	}
+
+	timeout := cfg.Timeout
+	if timeout == "" {
+		timeout = defaultTimeout
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse timeout string as time.Duration: %s", err)
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	maxRetries := defaultMaxRetries
+	if cfg.MaxRetries != nil {
+		maxRetries = *cfg.MaxRetries
+	}
+
+	ua, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user agent from context: %w", err)
+	}
+
+	return &EmbeddingModel{
+		Config:     cfg,
+		client:     &http.Client{Timeout: duration},
+		endpoint:   strings.TrimSuffix(baseUrl, "/") + "/embeddings",
+		apiKey:     apiKey,
+		userAgent:  ua,
+		batchSize:  batchSize,
+		maxRetries: maxRetries,
+	}, nil
+}
+
+var _ embeddingmodels.EmbeddingModel = &EmbeddingModel{}
+
+type EmbeddingModel struct {
+	Config
+	client     *http.Client
+	endpoint   string
+	apiKey     string
+	userAgent  string
+	batchSize  int
+	maxRetries int
+}
+
+// Returns the embedding model type
+func (m *EmbeddingModel) EmbeddingModelType() string {
+	return EmbeddingModelType
+}
+
+func (m *EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return m.Config
+}
+
+// EmbedParameters embeds the parameters in batches of at most batchSize
+// inputs, returning the embeddings in the order of the parameters.
+func (m *EmbeddingModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(parameters))
+	for start := 0; start < len(parameters); start += m.batchSize {
+		end := min(start+m.batchSize, len(parameters))
+		batch, err := m.embedBatch(ctx, parameters[start:end])
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return embeddings, nil
+}
+
+type embeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int32    `json:"dimensions,omitempty"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// embedBatch sends a single request, retrying it on transient failures.
+func (m *EmbeddingModel) embedBatch(ctx context.Context, inputs []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: m.Model, Input: inputs, Dimensions: m.Dimension})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal embedding request: %w", err)
+	}
+
+	delay := initialRetryDelay
+	for attempt := 0; ; attempt++ {
+		respBody, retryAfter, err := m.send(ctx, body)
+		if err == nil {
+			return parseEmbeddings(respBody, len(inputs))
+		}
+		if retryAfter < 0 || attempt >= m.maxRetries {
+			return nil, err
+		}
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// send posts the request body. On failure, it returns the delay before the
+// request can be retried: 0 for the default backoff, or a negative delay if
+// the request must not be retried.
+func (m *EmbeddingModel) send(ctx context.Context, body []byte) ([]byte, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, -1, fmt.Errorf("unable to create embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", m.userAgent)
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+	for k, v := range m.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, -1, err
+		}
+		return nil, 0, fmt.Errorf("unable to send embedding request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to read embedding response: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return respBody, 0, nil
+	}
+
+	err = fmt.Errorf("embedding request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return nil, -1, err
+	}
+	if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds >= 0 {
+		retryAfter := time.Duration(seconds) * time.Second
+		// fail instead of waiting longer than a request may take
+		if retryAfter > m.client.Timeout {
+			return nil, -1, fmt.Errorf("%w: Retry-After of %s exceeds the timeout of %s", err, retryAfter, m.client.Timeout)
+		}
+		// a zero Retry-After retries immediately
+		return nil, max(retryAfter, time.Nanosecond), err
+	}
+	return nil, 0, err
+}
+
+// parseEmbeddings returns the embeddings of the response in the order of the
+// inputs.
+func parseEmbeddings(body []byte, n int) ([][]float32, error) {
+	var resp embeddingResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unable to parse embedding response: %w", err)
+	}
+	if len(resp.Data) != n {
+		return nil, fmt.Errorf("embedding response has %d embeddings, expected %d", len(resp.Data), n)
+	}
+	sort.Slice(resp.Data, func(i, j int) bool { return resp.Data[i].Index < resp.Data[j].Index })
+	embeddings := make([][]float32, 0, n)
+	for _, d := range resp.Data {
+		embeddings = append(embeddings, d.Embedding)
+	}
+	return embeddings, nil
+}
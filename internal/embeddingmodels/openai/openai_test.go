@@ -0,0 +1,239 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlOpenAI(t *testing.T) {
+	retries := 5
+	tcs := []struct {
+		desc string
+		in   string
+		want server.EmbeddingModelConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: embeddingModel
+            name: my-openai-model
+            type: openai
+            model: text-embedding-3-small
+            `,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-openai-model": openai.Config{
+					Name:  "my-openai-model",
+					Type:  openai.EmbeddingModelType,
+					Model: "text-embedding-3-small",
+				},
+			},
+		},
+		{
+			desc: "self-hosted server",
+			in: `
+            kind: embeddingModel
+            name: my-ollama-model
+            type: openai
+            model: nomic-embed-text
+            baseUrl: http://localhost:11434/v1
+            apiKey: my-key
+            dimension: 768
+            headers:
+                X-Tenant: my-tenant
+            batchSize: 16
+            maxRetries: 5
+            timeout: 10s
+            `,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-ollama-model": openai.Config{
+					Name:       "my-ollama-model",
+					Type:       openai.EmbeddingModelType,
+					Model:      "nomic-embed-text",
+					BaseUrl:    "http://localhost:11434/v1",
+					ApiKey:     "my-key",
+					Dimension:  768,
+					Headers:    map[string]string{"X-Tenant": "my-tenant"},
+					BatchSize:  16,
+					MaxRetries: &retries,
+					Timeout:    "10s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, got, _, _, _, err := server.UnmarshalResourceConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("incorrect parse: %v", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+// fakeServer serves `/v1/embeddings`, embedding each input as [index of the
+// input in the request, length of the input]. The first `failures` requests
+// fail with the status, asking to retry after `retryAfter` seconds.
+func fakeServer(t *testing.T, failures int32, status int, retryAfter string, requests *[]map[string]any) *httptest.Server {
+	var calls atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if calls.Add(1) <= failures {
+			w.Header().Set("Retry-After", retryAfter)
+			http.Error(w, `{"error": "try again"}`, status)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		body["authorization"] = r.Header.Get("Authorization")
+		body["x-tenant"] = r.Header.Get("X-Tenant")
+		*requests = append(*requests, body)
+
+		// return the embeddings in reverse order, as the order of the
+		// response isn't guaranteed
+		inputs := body["input"].([]any)
+		var data []map[string]any
+		for i := len(inputs) - 1; i >= 0; i-- {
+			data = append(data, map[string]any{
+				"object":    "embedding",
+				"index":     i,
+				"embedding": []float32{float32(i), float32(len(inputs[i].(string)))},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+	}))
+}
+
+func TestEmbedParameters(t *testing.T) {
+	ctx := testutils.ContextWithUserAgent(context.Background(), "test-user-agent")
+	noRetries := 0
+
+	tcs := []struct {
+		desc         string
+		cfg          openai.Config
+		failures     int32
+		status       int
+		retryAfter   string
+		want         [][]float32
+		wantRequests int
+		wantErr      string
+	}{
+		{
+			desc:         "batches",
+			cfg:          openai.Config{BatchSize: 2, ApiKey: "my-key", Dimension: 2, Headers: map[string]string{"X-Tenant": "my-tenant"}},
+			want:         [][]float32{{0, 1}, {1, 2}, {0, 3}, {1, 4}, {0, 5}},
+			wantRequests: 3,
+		},
+		{
+			desc:         "retries transient failures",
+			cfg:          openai.Config{},
+			failures:     2,
+			status:       http.StatusTooManyRequests,
+			want:         [][]float32{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}},
+			wantRequests: 1,
+		},
+		{
+			desc:     "too many failures",
+			cfg:      openai.Config{MaxRetries: &noRetries},
+			failures: 1,
+			status:   http.StatusServiceUnavailable,
+			wantErr:  "embedding request failed with status 503",
+		},
+		{
+			desc:       "retry after the timeout",
+			cfg:        openai.Config{Timeout: "1s"},
+			failures:   1,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "3600",
+			wantErr:    "Retry-After of 1h0m0s exceeds the timeout of 1s",
+		},
+		{
+			desc:     "client error",
+			cfg:      openai.Config{},
+			failures: 1,
+			status:   http.StatusBadRequest,
+			wantErr:  "embedding request failed with status 400",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var requests []map[string]any
+			retryAfter := tc.retryAfter
+			if retryAfter == "" {
+				retryAfter = "0"
+			}
+			ts := fakeServer(t, tc.failures, tc.status, retryAfter, &requests)
+			defer ts.Close()
+
+			cfg := tc.cfg
+			cfg.Name, cfg.Type, cfg.Model, cfg.BaseUrl = "my-model", openai.EmbeddingModelType, "my-embedding", ts.URL+"/v1/"
+			m, err := cfg.Initialize(ctx)
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			got, err := m.EmbedParameters(ctx, []string{"a", "bb", "ccc", "dddd", "eeeee"})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("incorrect embeddings: diff %v", diff)
+			}
+			if len(requests) != tc.wantRequests {
+				t.Fatalf("expected %d requests, got %d", tc.wantRequests, len(requests))
+			}
+			req := requests[0]
+			if req["model"] != "my-embedding" {
+				t.Errorf("unexpected model %v", req["model"])
+			}
+			if tc.cfg.ApiKey != "" && req["authorization"] != "Bearer "+tc.cfg.ApiKey {
+				t.Errorf("unexpected authorization %v", req["authorization"])
+			}
+			if tc.cfg.Dimension != 0 && req["dimensions"] != float64(tc.cfg.Dimension) {
+				t.Errorf("unexpected dimensions %v", req["dimensions"])
+			}
+			if tc.cfg.Dimension == 0 && req["dimensions"] != nil {
+				t.Errorf("unexpected dimensions %v", req["dimensions"])
+			}
+			if want := tc.cfg.Headers["X-Tenant"]; req["x-tenant"] != want {
+				t.Errorf("unexpected X-Tenant header %v", req["x-tenant"])
+			}
+		})
+	}
+}
@@ -50,6 +50,9 @@ type Config struct {
 	Description string            `yaml:"description,omitempty"`
 	Messages    []Message         `yaml:"messages"`
 	Arguments   prompts.Arguments `yaml:"arguments,omitempty"`
+	// Partials are named templates the messages can include with
+	// `{{template "name" .}}`.
+	Partials map[string]string `yaml:"partials,omitempty"`
 }
 
 // Interface compliance checks.
@@ -61,8 +64,13 @@ func (c Config) PromptConfigType() string {
 }
 
 func (c Config) Initialize() (prompts.Prompt, error) {
+	templates, err := prompts.ParseMessages(c.Messages, c.Partials)
+	if err != nil {
+		return nil, fmt.Errorf("invalid messages: %w", err)
+	}
 	p := Prompt{
 		Config:      c,
+		templates:   templates,
 		manifest:    prompts.GetManifest(c.Description, c.Arguments),
 		mcpManifest: prompts.GetMcpManifest(c.Name, c.Description, c.Arguments),
 	}
@@ -71,6 +79,7 @@ func (c Config) Initialize() (prompts.Prompt, error) {
 
 type Prompt struct {
 	Config
+	templates   *prompts.MessageTemplates
 	manifest    prompts.Manifest
 	mcpManifest prompts.McpManifest
 }
@@ -88,7 +97,7 @@ func (p Prompt) McpManifest() prompts.McpManifest {
 }
 
 func (p Prompt) SubstituteParams(argValues parameters.ParamValues) (any, error) {
-	return prompts.SubstituteMessages(p.Messages, p.Arguments, argValues)
+	return p.templates.Execute(p.Arguments, argValues)
 }
 
 func (p Prompt) ParseArgs(args map[string]any, data map[string]map[string]any) (parameters.ParamValues, error) {
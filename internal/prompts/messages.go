@@ -15,15 +15,61 @@
 package prompts
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
 
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
 // Message represents a single message in a prompt, with a role and content.
+// The content is text by default, or one of an image, an embedded resource or
+// the result of a tool. When is a template condition, such as `.verbose`,
+// that must hold for the message to be included.
 type Message struct {
-	Role    string `yaml:"role,omitempty"`
-	Content string `yaml:"content"`
+	Role     string    `yaml:"role,omitempty"`
+	When     string    `yaml:"when,omitempty"`
+	Content  string    `yaml:"content,omitempty"`
+	Image    *Image    `yaml:"image,omitempty"`
+	Resource *Resource `yaml:"resource,omitempty"`
+	Tool     *ToolCall `yaml:"tool,omitempty"`
+}
+
+// Image is an image loaded from a file or a URL when the prompt is retrieved.
+type Image struct {
+	Path string `yaml:"path,omitempty"`
+	URL  string `yaml:"url,omitempty"`
+	// MimeType is detected from the image if it is not set.
+	MimeType string `yaml:"mimeType,omitempty"`
+	// Data is the base64-encoded image, set by ResolveMessages.
+	Data string `yaml:"-"`
+}
+
+// Resource is a resource embedded in a message. Its content is either the
+// text, which can use the arguments of the prompt, or the content of the file
+// at path.
+type Resource struct {
+	URI      string `yaml:"uri"`
+	MimeType string `yaml:"mimeType,omitempty"`
+	Text     string `yaml:"text,omitempty"`
+	Path     string `yaml:"path,omitempty"`
+	// Blob is the base64-encoded content of a binary file, set by
+	// ResolveMessages.
+	Blob string `yaml:"-"`
+}
+
+// ToolCall is a tool run with the arguments of the prompt when the prompt is
+// retrieved. The result of the tool becomes the text of the message.
+type ToolCall struct {
+	Name string `yaml:"name"`
 }
 
 const (
@@ -31,6 +77,10 @@ const (
 	assistantRole = "assistant"
 )
 
+// maxContentSize is the maximum size of the images and resources loaded into
+// messages.
+const maxContentSize = 10 << 20
+
 func (m *Message) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// Use a type alias to prevent an infinite recursion loop. The alias
 	// has the same fields but lacks the UnmarshalYAML method.
@@ -47,31 +97,229 @@ func (m *Message) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if m.Role != userRole && m.Role != assistantRole {
 		return fmt.Errorf("invalid role %q: must be 'user' or 'assistant'", m.Role)
 	}
+	return m.validateContent()
+}
+
+// validateContent checks that the message has a single kind of content, and
+// that this content is complete.
+func (m *Message) validateContent() error {
+	kinds := 0
+	if m.Content != "" {
+		kinds++
+	}
+	if m.Image != nil {
+		kinds++
+		if (m.Image.Path == "") == (m.Image.URL == "") {
+			return fmt.Errorf("image must have exactly one of 'path' or 'url'")
+		}
+	}
+	if m.Resource != nil {
+		kinds++
+		if m.Resource.URI == "" {
+			return fmt.Errorf("resource must have a 'uri'")
+		}
+		if m.Resource.Text != "" && m.Resource.Path != "" {
+			return fmt.Errorf("resource can't have both 'text' and 'path'")
+		}
+	}
+	if m.Tool != nil {
+		kinds++
+		if m.Tool.Name == "" {
+			return fmt.Errorf("tool must have a 'name'")
+		}
+	}
+	if kinds > 1 {
+		return fmt.Errorf("message must have only one of 'content', 'image', 'resource' or 'tool'")
+	}
 	return nil
 }
 
 // SubstituteMessages takes a slice of Messages and a set of parameter values,
-// and returns a new slice with all template variables resolved.
+// and returns a new slice with all template variables resolved. Templates are
+// resolved in the text content and in the URI and text of resources. Prompts
+// should rather parse their messages once with ParseMessages.
 func SubstituteMessages(messages []Message, arguments Arguments, argValues parameters.ParamValues) ([]Message, error) {
-	substitutedMessages := make([]Message, 0, len(messages))
-	argsMap := argValues.AsMap()
-
-	var params parameters.Parameters
-	for _, arg := range arguments {
-		params = append(params, arg.Parameter)
+	t, err := ParseMessages(messages, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error substituting params for message: %w", err)
 	}
+	return t.Execute(arguments, argValues)
+}
+
+// ToolRunner runs the tool with the given name and the arguments of a prompt,
+// and returns its result.
+type ToolRunner func(ctx context.Context, name string, args map[string]any) (any, error)
 
+// ResolveMessages loads the images and the resource files of the messages, and
+// replaces the tool messages with the text of the tool results. It is called
+// each time the prompt is retrieved, after SubstituteMessages.
+func ResolveMessages(ctx context.Context, messages []Message, argValues parameters.ParamValues, runTool ToolRunner) ([]Message, error) {
+	resolved := make([]Message, 0, len(messages))
 	for _, msg := range messages {
-		substitutedContent, err := parameters.ResolveTemplateParams(params, msg.Content, argsMap)
+		switch {
+		case msg.Image != nil:
+			img := *msg.Image
+			data, mimeType, err := loadContent(ctx, img.Path, img.URL)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load image: %w", err)
+			}
+			if img.MimeType == "" {
+				img.MimeType = mimeType
+			}
+			img.Data = base64.StdEncoding.EncodeToString(data)
+			msg.Image = &img
+		case msg.Resource != nil && msg.Resource.Path != "":
+			r := *msg.Resource
+			data, mimeType, err := loadContent(ctx, r.Path, "")
+			if err != nil {
+				return nil, fmt.Errorf("unable to load resource %q: %w", r.URI, err)
+			}
+			if r.MimeType == "" {
+				r.MimeType = mimeType
+			}
+			if utf8.Valid(data) {
+				r.Text = string(data)
+			} else {
+				r.Blob = base64.StdEncoding.EncodeToString(data)
+			}
+			msg.Resource = &r
+		case msg.Tool != nil:
+			if runTool == nil {
+				return nil, fmt.Errorf("unable to run tool %q: tools are not available", msg.Tool.Name)
+			}
+			res, err := runTool(ctx, msg.Tool.Name, argValues.AsMap())
+			if err != nil {
+				return nil, fmt.Errorf("unable to run tool %q: %w", msg.Tool.Name, err)
+			}
+			text, err := resultText(res)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read result of tool %q: %w", msg.Tool.Name, err)
+			}
+			msg = Message{Role: msg.Role, Content: text}
+		}
+		resolved = append(resolved, msg)
+	}
+	return resolved, nil
+}
+
+// loadContent reads the file at path, or downloads url, and returns its
+// content and MIME type.
+func loadContent(ctx context.Context, path, url string) ([]byte, string, error) {
+	var data []byte
+	var mimeType string
+	if url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("unexpected status fetching %s: %s", url, resp.Status)
+		}
+		data, err = io.ReadAll(io.LimitReader(resp.Body, maxContentSize+1))
+		if err != nil {
+			return nil, "", err
+		}
+		mimeType, _, _ = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		path = req.URL.Path
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, "", err
+		}
+		defer f.Close()
+		data, err = io.ReadAll(io.LimitReader(f, maxContentSize+1))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if len(data) > maxContentSize {
+		return nil, "", fmt.Errorf("content is larger than %d bytes", maxContentSize)
+	}
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType, _, _ = mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(path)))
+	}
+	if mimeType == "" {
+		mimeType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	return data, mimeType, nil
+}
+
+// resultText formats the result of a tool as text. Strings and formatted
+// results are kept as is, and other results are encoded as JSON.
+func resultText(res any) (string, error) {
+	switch res := res.(type) {
+	case string:
+		return res, nil
+	case tools.FormattedResult:
+		return string(res), nil
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// GetMessages renders the messages of the prompt with the argument values. It
+// queries the data of a QueryPrompt, substitutes the arguments into the
+// messages, and resolves their images, resources and tools.
+func GetMessages(ctx context.Context, p Prompt, resourceMgr SourceProvider, argValues parameters.ParamValues, runTool ToolRunner) ([]Message, error) {
+	if qp, ok := p.(QueryPrompt); ok {
+		var err error
+		argValues, err = qp.Query(ctx, resourceMgr, argValues)
 		if err != nil {
-			return nil, fmt.Errorf("error substituting params for message: %w", err)
+			return nil, fmt.Errorf("error querying data: %w", err)
 		}
+	}
+
+	substituted, err := p.SubstituteParams(argValues)
+	if err != nil {
+		return nil, fmt.Errorf("error substituting params: %w", err)
+	}
+	messages, ok := substituted.([]Message)
+	if !ok {
+		return nil, fmt.Errorf("internal error: SubstituteParams returned unexpected type")
+	}
 
-		substitutedMessages = append(substitutedMessages, Message{
-			Role:    msg.Role,
-			Content: substitutedContent,
-		})
+	messages, err = ResolveMessages(ctx, messages, argValues, runTool)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving messages: %w", err)
 	}
+	return messages, nil
+}
+
+// RenderedMessage is the JSON representation of a rendered message, returned
+// by the HTTP API and the CLI. Its content follows the MCP content types.
+type RenderedMessage struct {
+	Role    string         `json:"role"`
+	Content map[string]any `json:"content"`
+}
 
-	return substitutedMessages, nil
+// Rendered returns the JSON representation of a message returned by
+// GetMessages.
+func (m Message) Rendered() RenderedMessage {
+	var content map[string]any
+	switch {
+	case m.Image != nil:
+		content = map[string]any{"type": "image", "data": m.Image.Data, "mimeType": m.Image.MimeType}
+	case m.Resource != nil:
+		resource := map[string]any{"uri": m.Resource.URI}
+		if m.Resource.MimeType != "" {
+			resource["mimeType"] = m.Resource.MimeType
+		}
+		if m.Resource.Blob != "" {
+			resource["blob"] = m.Resource.Blob
+		} else {
+			resource["text"] = m.Resource.Text
+		}
+		content = map[string]any{"type": "resource", "resource": resource}
+	default:
+		content = map[string]any{"type": "text", "text": m.Content}
+	}
+	return RenderedMessage{Role: m.Role, Content: content}
 }
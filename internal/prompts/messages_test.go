@@ -15,6 +15,11 @@
 package prompts_test
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -47,6 +52,31 @@ func TestMessageUnmarshalYAML(t *testing.T) {
 			yamlInput: map[string]any{"content": "A message with no role"},
 			want:      prompts.Message{Role: "user", Content: "A message with no role"},
 		},
+		{
+			name:      "Image from a file",
+			yamlInput: map[string]any{"image": map[string]any{"path": "diagram.png"}},
+			want:      prompts.Message{Role: "user", Image: &prompts.Image{Path: "diagram.png"}},
+		},
+		{
+			name:      "Tool content",
+			yamlInput: map[string]any{"role": "assistant", "tool": map[string]any{"name": "get_schema"}},
+			want:      prompts.Message{Role: "assistant", Tool: &prompts.ToolCall{Name: "get_schema"}},
+		},
+		{
+			name:      "Image without path or url",
+			yamlInput: map[string]any{"image": map[string]any{"mimeType": "image/png"}},
+			wantErr:   "image must have exactly one of 'path' or 'url'",
+		},
+		{
+			name:      "Resource without uri",
+			yamlInput: map[string]any{"resource": map[string]any{"text": "notes"}},
+			wantErr:   "resource must have a 'uri'",
+		},
+		{
+			name:      "Several kinds of content",
+			yamlInput: map[string]any{"content": "Hello", "tool": map[string]any{"name": "get_schema"}},
+			wantErr:   "message must have only one of 'content', 'image', 'resource' or 'tool'",
+		},
 		{
 			name:      "Invalid role: other",
 			yamlInput: map[string]any{"role": "other", "content": "Some other role"},
@@ -131,3 +161,76 @@ func TestSubstituteMessages(t *testing.T) {
 		}
 	})
 }
+
+func TestSubstituteMessagesResource(t *testing.T) {
+	t.Parallel()
+	arguments := prompts.Arguments{
+		{Parameter: parameters.NewStringParameter("table", "The table name.")},
+	}
+	messages := []prompts.Message{
+		{Role: "user", Resource: &prompts.Resource{URI: "db://tables/{{.table}}", Text: "Table {{.table}}"}},
+	}
+	argValues := parameters.ParamValues{{Name: "table", Value: "orders"}}
+
+	want := []prompts.Message{
+		{Role: "user", Resource: &prompts.Resource{URI: "db://tables/orders", Text: "Table orders"}},
+	}
+	got, err := prompts.SubstituteMessages(messages, arguments, argValues)
+	if err != nil {
+		t.Fatalf("SubstituteMessages() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SubstituteMessages() mismatch (-want +got):\n%s", diff)
+	}
+	if messages[0].Resource.URI != "db://tables/{{.table}}" {
+		t.Errorf("SubstituteMessages() modified the original message")
+	}
+}
+
+func TestResolveMessages(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	png := []byte("\x89PNG\r\n\x1a\nimage")
+	if err := os.WriteFile(filepath.Join(dir, "diagram.png"), png, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"tables":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []prompts.Message{
+		{Role: "user", Content: "Describe the diagram."},
+		{Role: "user", Image: &prompts.Image{Path: filepath.Join(dir, "diagram.png")}},
+		{Role: "user", Resource: &prompts.Resource{URI: "file:///schema.json", Path: filepath.Join(dir, "schema.json")}},
+		{Role: "assistant", Tool: &prompts.ToolCall{Name: "get_schema"}},
+	}
+	argValues := parameters.ParamValues{{Name: "table", Value: "orders"}}
+	runTool := func(ctx context.Context, name string, args map[string]any) (any, error) {
+		return []any{map[string]any{"tool": name, "table": args["table"]}}, nil
+	}
+
+	want := []prompts.Message{
+		{Role: "user", Content: "Describe the diagram."},
+		{Role: "user", Image: &prompts.Image{Path: filepath.Join(dir, "diagram.png"), MimeType: "image/png", Data: base64.StdEncoding.EncodeToString(png)}},
+		{Role: "user", Resource: &prompts.Resource{URI: "file:///schema.json", Path: filepath.Join(dir, "schema.json"), MimeType: "application/json", Text: `{"tables":[]}`}},
+		{Role: "assistant", Content: `[{"table":"orders","tool":"get_schema"}]`},
+	}
+	got, err := prompts.ResolveMessages(context.Background(), messages, argValues, runTool)
+	if err != nil {
+		t.Fatalf("ResolveMessages() failed: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveMessages() mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("FailureTool", func(t *testing.T) {
+		failing := func(ctx context.Context, name string, args map[string]any) (any, error) {
+			return nil, fmt.Errorf("tool failed")
+		}
+		_, err := prompts.ResolveMessages(context.Background(), messages[3:], argValues, failing)
+		wantErr := `unable to run tool "get_schema": tool failed`
+		if err == nil || err.Error() != wantErr {
+			t.Errorf("error mismatch:\n  want: %q\n  got: %v", wantErr, err)
+		}
+	})
+}
@@ -17,8 +17,12 @@ package prompts
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 )
 
@@ -42,6 +46,11 @@ func Register(resourceType string, factory PromptConfigFactory) bool {
 	return true
 }
 
+// RegisteredTypes returns the sorted list of registered prompt types.
+func RegisteredTypes() []string {
+	return slices.Sorted(maps.Keys(promptRegistry))
+}
+
 // DecodeConfig looks up the registered factory for the given type and uses it
 // to decode the prompt configuration.
 func DecodeConfig(ctx context.Context, resourceType, name string, decoder *yaml.Decoder) (PromptConfig, error) {
@@ -75,7 +84,41 @@ type Prompt interface {
 	ToConfig() PromptConfig
 }
 
+// SourceProvider defines the minimal view of the server.ResourceManager
+// that the prompts package needs.
+// This is implemented to prevent import cycles.
+type SourceProvider interface {
+	GetSource(sourceName string) (sources.Source, bool)
+}
+
+// QueryPrompt is implemented by prompts whose messages are built from data
+// queried each time the prompt is retrieved.
+type QueryPrompt interface {
+	Prompt
+	// Query retrieves the data of the prompt, and returns the argument values
+	// with the data added, to be passed to SubstituteParams.
+	Query(ctx context.Context, resourceMgr SourceProvider, argValues parameters.ParamValues) (parameters.ParamValues, error)
+}
+
 // Manifest is the representation of prompts sent to Client SDKs.
+// AuthorizedPrompt is implemented by the prompts restricting who can get them,
+// such as the prompts querying a source.
+type AuthorizedPrompt interface {
+	Prompt
+	// Authorize checks the requirements of the prompt against the claims of
+	// the verified auth services, keyed by auth service name.
+	Authorize(claimsFromAuth map[string]map[string]any) util.ToolboxError
+}
+
+// Authorize checks that the caller can get the prompt, if the prompt restricts
+// who can get it.
+func Authorize(p Prompt, claimsFromAuth map[string]map[string]any) util.ToolboxError {
+	if ap, ok := p.(AuthorizedPrompt); ok {
+		return ap.Authorize(claimsFromAuth)
+	}
+	return nil
+}
+
 type Manifest struct {
 	Description string                         `json:"description"`
 	Arguments   []parameters.ParameterManifest `json:"arguments"`
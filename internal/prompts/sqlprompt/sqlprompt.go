@@ -0,0 +1,198 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlprompt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const resourceType = "sql"
+
+// RowsName is the name under which the rows returned by the statement are
+// available to the templates of the messages.
+const RowsName = "rows"
+
+// init registers this prompt type with the prompt framework.
+func init() {
+	if !prompts.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("prompt type %q already registered", resourceType))
+	}
+}
+
+// newConfig is the factory function for creating a sql prompt configuration.
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (prompts.PromptConfig, error) {
+	cfg := &Config{Name: name}
+	if err := decoder.DecodeContext(ctx, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// compatibleSource is implemented by the SQL sources taking positional
+// params that can run the statement in a read-only transaction, so that
+// getting the prompt can't modify the data.
+type compatibleSource interface {
+	ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error)
+}
+
+// Config is the configuration for a prompt whose messages are rendered from
+// the rows of a statement, executed each time the prompt is retrieved. The
+// arguments are the params of the statement, in order.
+type Config struct {
+	Name        string            `yaml:"name"`
+	Type        string            `yaml:"type" validate:"required"`
+	Description string            `yaml:"description,omitempty"`
+	Source      string            `yaml:"source" validate:"required"`
+	Statement   string            `yaml:"statement" validate:"required"`
+	Messages    []prompts.Message `yaml:"messages"`
+	Arguments   prompts.Arguments `yaml:"arguments,omitempty"`
+	Partials    map[string]string `yaml:"partials,omitempty"`
+	// AuthRequired lists the auth services the caller must be verified by
+	// to get the prompt.
+	AuthRequired []string `yaml:"authRequired,omitempty"`
+	// Policies must be satisfied by the caller to get the prompt.
+	Policies tools.Policies `yaml:"policies,omitempty"`
+}
+
+// Interface compliance checks.
+var _ prompts.PromptConfig = Config{}
+var _ prompts.QueryPrompt = Prompt{}
+var _ prompts.AuthorizedPrompt = Prompt{}
+
+func (c Config) PromptConfigType() string {
+	return resourceType
+}
+
+func (c Config) Initialize() (prompts.Prompt, error) {
+	for _, arg := range c.Arguments {
+		if arg.GetName() == RowsName {
+			return nil, fmt.Errorf("argument name %q is reserved for the rows of the statement", RowsName)
+		}
+	}
+	if err := c.Policies.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policies: %w", err)
+	}
+	templates, err := prompts.ParseMessages(c.Messages, c.Partials)
+	if err != nil {
+		return nil, fmt.Errorf("invalid messages: %w", err)
+	}
+	p := Prompt{
+		Config:      c,
+		templates:   templates,
+		manifest:    prompts.GetManifest(c.Description, c.Arguments),
+		mcpManifest: prompts.GetMcpManifest(c.Name, c.Description, c.Arguments),
+	}
+	return p, nil
+}
+
+type Prompt struct {
+	Config
+	templates   *prompts.MessageTemplates
+	manifest    prompts.Manifest
+	mcpManifest prompts.McpManifest
+}
+
+func (p Prompt) ToConfig() prompts.PromptConfig {
+	return p.Config
+}
+
+func (p Prompt) Manifest() prompts.Manifest {
+	return p.manifest
+}
+
+func (p Prompt) McpManifest() prompts.McpManifest {
+	return p.mcpManifest
+}
+
+// Authorize checks that the caller is verified by one of the auth services in
+// `authRequired`, and satisfies the `policies` of the prompt.
+func (p Prompt) Authorize(claimsFromAuth map[string]map[string]any) util.ToolboxError {
+	verifiedAuthServices := make([]string, 0, len(claimsFromAuth))
+	for name := range claimsFromAuth {
+		verifiedAuthServices = append(verifiedAuthServices, name)
+	}
+	if !tools.IsAuthorized(p.AuthRequired, verifiedAuthServices) {
+		return util.NewClientServerError("unauthorized prompt: please make sure you specify correct auth headers", http.StatusUnauthorized, nil)
+	}
+	return p.Policies.Check(claimsFromAuth)
+}
+
+// Query executes the statement with the arguments, and adds the rows to the
+// argument values.
+func (p Prompt) Query(ctx context.Context, resourceMgr prompts.SourceProvider, argValues parameters.ParamValues) (parameters.ParamValues, error) {
+	s, ok := resourceMgr.GetSource(p.Source)
+	if !ok {
+		return nil, fmt.Errorf("unable to retrieve source %q for prompt %q", p.Source, p.Name)
+	}
+	source, ok := s.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q prompt: source %q can't run read-only statements", resourceType, p.Source)
+	}
+
+	argsMap := argValues.AsMap()
+	params := make([]any, 0, len(p.Arguments))
+	for _, arg := range p.Arguments {
+		params = append(params, argsMap[arg.GetName()])
+	}
+
+	res, err := source.ReadOnlySQL(ctx, p.Statement, params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute statement: %w", err)
+	}
+	return append(argValues, parameters.ParamValue{Name: RowsName, Value: templateRows(res)}), nil
+}
+
+// templateRows converts the ordered rows returned by sources into maps, so
+// that templates can access their columns by name, such as `.title`.
+func templateRows(res any) []any {
+	rows, ok := res.([]any)
+	if !ok {
+		if res == nil {
+			return []any{}
+		}
+		return []any{res}
+	}
+	out := make([]any, len(rows))
+	for i, row := range rows {
+		switch r := row.(type) {
+		case orderedmap.Row:
+			m := make(map[string]any, len(r.Columns))
+			for _, c := range r.Columns {
+				m[c.Name] = c.Value
+			}
+			out[i] = m
+		default:
+			out[i] = row
+		}
+	}
+	return out
+}
+
+func (p Prompt) SubstituteParams(argValues parameters.ParamValues) (any, error) {
+	return p.templates.Execute(p.Arguments, argValues)
+}
+
+func (p Prompt) ParseArgs(args map[string]any, data map[string]map[string]any) (parameters.ParamValues, error) {
+	return prompts.ParseArguments(p.Arguments, args, data)
+}
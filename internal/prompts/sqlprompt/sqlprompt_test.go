@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlprompt_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/prompts/sqlprompt"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+type fakeSource struct {
+	statement string
+	params    []any
+}
+
+func (s *fakeSource) SourceType() string             { return "fake" }
+func (s *fakeSource) ToConfig() sources.SourceConfig { return nil }
+
+func (s *fakeSource) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	s.statement, s.params = statement, params
+	var first, second orderedmap.Row
+	first.Add("title", "Disk full")
+	first.Add("severity", "high")
+	second.Add("title", "Slow queries")
+	second.Add("severity", "low")
+	return []any{first, second}, nil
+}
+
+// readWriteSource can only run statements read-write.
+type readWriteSource struct{}
+
+func (readWriteSource) SourceType() string             { return "fake" }
+func (readWriteSource) ToConfig() sources.SourceConfig { return nil }
+
+func (readWriteSource) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	return nil, nil
+}
+
+type fakeProvider map[string]sources.Source
+
+func (p fakeProvider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestPrompt(t *testing.T) {
+	t.Parallel()
+	cfg := sqlprompt.Config{
+		Name:        "triage",
+		Type:        "sql",
+		Description: "Triage the latest incidents.",
+		Source:      "incidents-db",
+		Statement:   "SELECT title, severity FROM incidents WHERE service = $1 LIMIT $2",
+		Arguments: prompts.Arguments{
+			{Parameter: parameters.NewStringParameter("service", "The service.")},
+			{Parameter: parameters.NewIntParameterWithDefault("limit", 5, "The number of incidents.")},
+		},
+		Messages: []prompts.Message{
+			{Role: "user", Content: "Triage the incidents of {{.service}}:\n{{range .rows}}- [{{.severity}}] {{.title}}\n{{end}}"},
+		},
+	}
+	p, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+
+	argValues, err := p.ParseArgs(map[string]any{"service": "checkout"}, nil)
+	if err != nil {
+		t.Fatalf("ParseArgs() failed: %v", err)
+	}
+	source := &fakeSource{}
+	argValues, err = p.(prompts.QueryPrompt).Query(context.Background(), fakeProvider{"incidents-db": source}, argValues)
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	if diff := cmp.Diff([]any{"checkout", 5}, source.params); diff != "" {
+		t.Errorf("incorrect statement params (-want +got):\n%s", diff)
+	}
+
+	got, err := p.SubstituteParams(argValues)
+	if err != nil {
+		t.Fatalf("SubstituteParams() failed: %v", err)
+	}
+	want := []prompts.Message{
+		{Role: "user", Content: "Triage the incidents of checkout:\n- [high] Disk full\n- [low] Slow queries\n"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SubstituteParams() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPromptErrors(t *testing.T) {
+	t.Parallel()
+	t.Run("reserved argument", func(t *testing.T) {
+		cfg := sqlprompt.Config{
+			Name:      "p",
+			Source:    "db",
+			Statement: "SELECT 1",
+			Arguments: prompts.Arguments{{Parameter: parameters.NewStringParameter("rows", "rows")}},
+		}
+		_, err := cfg.Initialize()
+		if err == nil || !strings.Contains(err.Error(), `argument name "rows" is reserved`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("missing source", func(t *testing.T) {
+		cfg := sqlprompt.Config{Name: "p", Source: "db", Statement: "SELECT 1"}
+		p, err := cfg.Initialize()
+		if err != nil {
+			t.Fatalf("Initialize() failed: %v", err)
+		}
+		_, err = p.(prompts.QueryPrompt).Query(context.Background(), fakeProvider{}, nil)
+		if err == nil || !strings.Contains(err.Error(), `unable to retrieve source "db"`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("read-write source", func(t *testing.T) {
+		cfg := sqlprompt.Config{Name: "p", Source: "db", Statement: "SELECT 1"}
+		p, err := cfg.Initialize()
+		if err != nil {
+			t.Fatalf("Initialize() failed: %v", err)
+		}
+		_, err = p.(prompts.QueryPrompt).Query(context.Background(), fakeProvider{"db": readWriteSource{}}, nil)
+		if err == nil || !strings.Contains(err.Error(), `source "db" can't run read-only statements`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("invalid policies", func(t *testing.T) {
+		cfg := sqlprompt.Config{Name: "p", Source: "db", Statement: "SELECT 1", Policies: tools.Policies{{Claim: "email"}}}
+		_, err := cfg.Initialize()
+		if err == nil || !strings.Contains(err.Error(), "invalid policies") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestPromptAuthorize(t *testing.T) {
+	t.Parallel()
+	cfg := sqlprompt.Config{
+		Name:         "p",
+		Source:       "db",
+		Statement:    "SELECT 1",
+		AuthRequired: []string{"my-auth"},
+		Policies:     tools.Policies{{Claim: "email", EndsWith: "@example.com"}},
+	}
+	p, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+
+	tcs := []struct {
+		desc   string
+		claims map[string]map[string]any
+		want   int
+	}{
+		{desc: "authorized", claims: map[string]map[string]any{"my-auth": {"email": "jane@example.com"}}},
+		{desc: "no claims", want: http.StatusUnauthorized},
+		{desc: "other auth service", claims: map[string]map[string]any{"other-auth": {"email": "jane@example.com"}}, want: http.StatusUnauthorized},
+		{desc: "policy not satisfied", claims: map[string]map[string]any{"my-auth": {"email": "jane@other.com"}}, want: http.StatusForbidden},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := prompts.Authorize(p, tc.claims)
+			if tc.want == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var clientServerErr *util.ClientServerError
+			if !errors.As(err, &clientServerErr) || clientServerErr.Code != tc.want {
+				t.Fatalf("got error %v, want status %d", err, tc.want)
+			}
+		})
+	}
+}
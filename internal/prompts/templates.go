@@ -0,0 +1,236 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// TemplateFuncs are the functions available to prompt templates, on top of
+// the builtin functions of text/template. They only format their arguments,
+// so templates can't reach the environment of the server.
+var TemplateFuncs = template.FuncMap{
+	"array":   parameters.ConvertArrayParamToString,
+	"default": defaultValue,
+	"join":    join,
+	"json":    toJSON,
+	"yaml":    toYAML,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"trim":    strings.TrimSpace,
+}
+
+// defaultValue returns def if v is missing or empty.
+func defaultValue(def, v any) any {
+	if v == nil {
+		return def
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		if rv.Len() == 0 {
+			return def
+		}
+	}
+	return v
+}
+
+// join joins the items of the list with sep. It is used as
+// `{{join ", " .tags}}` or `{{.tags | join ", "}}`.
+func join(sep string, list any) (string, error) {
+	if list == nil {
+		return "", nil
+	}
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+	items := make([]string, rv.Len())
+	for i := range items {
+		items[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(items, sep), nil
+}
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func toYAML(v any) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+// MessageTemplates are the parsed templates of the messages of a prompt,
+// along with the partials they can include with `{{template "name" .}}`.
+type MessageTemplates struct {
+	messages []Message
+	tmpl     *template.Template
+}
+
+// messageTemplate names the template of a part of the i-th message. It can't
+// collide with the name of a partial, which can't contain a "#".
+func messageTemplate(i int, part string) string {
+	return fmt.Sprintf("#%d/%s", i, part)
+}
+
+// ParseMessages parses the templates of the messages and the partials, and
+// checks that every partial they include is defined. It is called when the
+// prompt is initialized, so that template errors fail the config load.
+func ParseMessages(messages []Message, partials map[string]string) (*MessageTemplates, error) {
+	root := template.New("").Funcs(TemplateFuncs)
+	for name, text := range partials {
+		if name == "" || strings.Contains(name, "#") {
+			return nil, fmt.Errorf("invalid partial name %q", name)
+		}
+		if _, err := root.New(name).Parse(text); err != nil {
+			return nil, fmt.Errorf("error parsing partial %q: %w", name, err)
+		}
+	}
+
+	add := func(name, text string) error {
+		_, err := root.New(name).Parse(text)
+		return err
+	}
+	for i, msg := range messages {
+		if err := add(messageTemplate(i, "content"), msg.Content); err != nil {
+			return nil, fmt.Errorf("error parsing message %d: %w", i, err)
+		}
+		if msg.When != "" {
+			if err := add(messageTemplate(i, "when"), "{{if "+msg.When+"}}true{{end}}"); err != nil {
+				return nil, fmt.Errorf("error parsing condition of message %d: %w", i, err)
+			}
+		}
+		if msg.Resource != nil {
+			if err := add(messageTemplate(i, "uri"), msg.Resource.URI); err != nil {
+				return nil, fmt.Errorf("error parsing resource uri of message %d: %w", i, err)
+			}
+			if err := add(messageTemplate(i, "text"), msg.Resource.Text); err != nil {
+				return nil, fmt.Errorf("error parsing resource text of message %d: %w", i, err)
+			}
+		}
+	}
+
+	for _, t := range root.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		for _, name := range includedTemplates(t.Tree.Root) {
+			if root.Lookup(name) == nil {
+				return nil, fmt.Errorf("partial %q is not defined", name)
+			}
+		}
+	}
+	return &MessageTemplates{messages: messages, tmpl: root}, nil
+}
+
+// includedTemplates returns the names of the templates included by node.
+func includedTemplates(node parse.Node) []string {
+	var names []string
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			names = append(names, includedTemplates(c)...)
+		}
+	case *parse.TemplateNode:
+		names = append(names, n.Name)
+	case *parse.IfNode:
+		names = append(names, includedTemplates(n.List)...)
+		names = append(names, includedTemplates(n.ElseList)...)
+	case *parse.RangeNode:
+		names = append(names, includedTemplates(n.List)...)
+		names = append(names, includedTemplates(n.ElseList)...)
+	case *parse.WithNode:
+		names = append(names, includedTemplates(n.List)...)
+		names = append(names, includedTemplates(n.ElseList)...)
+	}
+	return names
+}
+
+// Execute renders the messages with the values of the arguments. Messages
+// whose `when` condition doesn't hold are left out.
+func (t *MessageTemplates) Execute(arguments Arguments, argValues parameters.ParamValues) ([]Message, error) {
+	// missing optional arguments are nil, so that templates can test them.
+	// The values added by QueryPrompt.Query are available as well.
+	data := argValues.AsMap()
+	for _, arg := range arguments {
+		if _, ok := data[arg.GetName()]; !ok {
+			data[arg.GetName()] = nil
+		}
+	}
+
+	render := func(name string) (string, error) {
+		var buf bytes.Buffer
+		if err := t.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", fmt.Errorf("error executing go template %s", err)
+		}
+		return buf.String(), nil
+	}
+
+	substitutedMessages := make([]Message, 0, len(t.messages))
+	for i, msg := range t.messages {
+		if msg.When != "" {
+			cond, err := render(messageTemplate(i, "when"))
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating condition of message: %w", err)
+			}
+			if cond == "" {
+				continue
+			}
+		}
+
+		content, err := render(messageTemplate(i, "content"))
+		if err != nil {
+			return nil, fmt.Errorf("error substituting params for message: %w", err)
+		}
+		substituted := Message{
+			Role:    msg.Role,
+			Content: content,
+			Image:   msg.Image,
+			Tool:    msg.Tool,
+		}
+		if msg.Resource != nil {
+			r := *msg.Resource
+			if r.URI, err = render(messageTemplate(i, "uri")); err != nil {
+				return nil, fmt.Errorf("error substituting params for resource uri: %w", err)
+			}
+			if r.Text, err = render(messageTemplate(i, "text")); err != nil {
+				return nil, fmt.Errorf("error substituting params for resource text: %w", err)
+			}
+			substituted.Resource = &r
+		}
+		substitutedMessages = append(substitutedMessages, substituted)
+	}
+	return substitutedMessages, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func TestMessageTemplatesExecute(t *testing.T) {
+	t.Parallel()
+	arguments := prompts.Arguments{
+		{Parameter: parameters.NewStringParameter("name", "The name.")},
+		{Parameter: parameters.NewStringParameterWithRequired("tone", "The tone.", false)},
+		{Parameter: parameters.NewArrayParameterWithRequired("tags", "The tags.", false, parameters.NewStringParameter("tag", "A tag."))},
+	}
+	partials := map[string]string{
+		"greeting": "Hello {{.name | upper}}",
+	}
+	tcs := []struct {
+		desc      string
+		messages  []prompts.Message
+		argValues parameters.ParamValues
+		want      []prompts.Message
+	}{
+		{
+			desc: "functions",
+			messages: []prompts.Message{
+				{Role: "user", Content: `{{.name | lower}}: {{default "neutral" .tone}}, {{join ", " .tags}}, {{json .tags}}`},
+				{Role: "user", Content: "{{yaml .tags}}"},
+			},
+			argValues: parameters.ParamValues{
+				{Name: "name", Value: "Alice"},
+				{Name: "tags", Value: []any{"a", "b"}},
+			},
+			want: []prompts.Message{
+				{Role: "user", Content: `alice: neutral, a, b, ["a","b"]`},
+				{Role: "user", Content: "- a\n- b"},
+			},
+		},
+		{
+			desc: "conditional messages",
+			messages: []prompts.Message{
+				{Role: "user", Content: "{{template \"greeting\" .}}{{if .tone}} in a {{.tone}} tone{{end}}."},
+				{Role: "user", When: ".tags", Content: "Tags: {{join \", \" .tags}}"},
+				{Role: "assistant", When: `eq .tone "formal"`, Content: "Certainly."},
+			},
+			argValues: parameters.ParamValues{
+				{Name: "name", Value: "Alice"},
+				{Name: "tone", Value: "formal"},
+			},
+			want: []prompts.Message{
+				{Role: "user", Content: "Hello ALICE in a formal tone."},
+				{Role: "assistant", Content: "Certainly."},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			templates, err := prompts.ParseMessages(tc.messages, partials)
+			if err != nil {
+				t.Fatalf("ParseMessages() failed: %v", err)
+			}
+			got, err := templates.Execute(arguments, tc.argValues)
+			if err != nil {
+				t.Fatalf("Execute() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Execute() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseMessagesErrors(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		desc     string
+		messages []prompts.Message
+		partials map[string]string
+		wantErr  string
+	}{
+		{
+			desc:     "undefined partial",
+			messages: []prompts.Message{{Content: `{{if .a}}{{template "missing" .}}{{end}}`}},
+			wantErr:  `partial "missing" is not defined`,
+		},
+		{
+			desc:     "undefined function",
+			messages: []prompts.Message{{Content: "{{env \"HOME\"}}"}},
+			wantErr:  `function "env" not defined`,
+		},
+		{
+			desc:     "invalid condition",
+			messages: []prompts.Message{{When: "{{.a}}", Content: "a"}},
+			wantErr:  "error parsing condition of message 0",
+		},
+		{
+			desc:     "invalid partial",
+			messages: []prompts.Message{{Content: "a"}},
+			partials: map[string]string{"p": "{{.unclosed"},
+			wantErr:  `error parsing partial "p"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := prompts.ParseMessages(tc.messages, tc.partials)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -46,6 +49,14 @@ func apiRouter(s *Server) (chi.Router, error) {
 		r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
 	})
 
+	r.Get("/promptset", func(w http.ResponseWriter, r *http.Request) { promptsetHandler(s, w, r) })
+	r.Get("/promptset/{promptsetName}", func(w http.ResponseWriter, r *http.Request) { promptsetHandler(s, w, r) })
+
+	r.Route("/prompt/{promptName}", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { promptGetHandler(s, w, r) })
+		r.Post("/get", func(w http.ResponseWriter, r *http.Request) { promptRenderHandler(s, w, r) })
+	})
+
 	return r, nil
 }
 
@@ -72,6 +83,16 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+
+	// only list the tools the caller is authorized to use
+	claimsFromAuth := auth.ClaimsFromRequest(ctx, s.ResourceMgr.GetAuthServiceMap(), r.Header)
+	toolset, tbErr := toolset.Authorize(claimsFromAuth)
+	if tbErr != nil {
+		err = tbErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
 	render.JSON(w, r, toolset.Manifest)
 }
 
@@ -157,19 +178,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 	// Tool authentication
 	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
-	claimsFromAuth := make(map[string]map[string]any)
-	for _, aS := range s.ResourceMgr.GetAuthServiceMap() {
-		claims, err := aS.GetClaimsFromHeader(ctx, r.Header)
-		if err != nil {
-			s.logger.DebugContext(ctx, err.Error())
-			continue
-		}
-		if claims == nil {
-			// authService not present in header
-			continue
-		}
-		claimsFromAuth[aS.GetName()] = claims
-	}
+	claimsFromAuth := auth.ClaimsFromRequest(ctx, s.ResourceMgr.GetAuthServiceMap(), r.Header)
 
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
@@ -187,7 +196,20 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
 		return
 	}
+
+	// Check the authorization policies of the tool
+	if tbErr := tools.CheckPolicies(tool, claimsFromAuth); tbErr != nil {
+		err = tbErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
+	// make the verified tokens of the caller available to sources exchanging
+	// them for downstream credentials
+	ctx = auth.WithSubjectTokens(ctx, auth.SubjectTokensFromRequest(ctx, claimsFromAuth, r.Header))
+	// let the tools calling other tools run them on behalf of the caller
+	ctx = tools.WithToolRunner(ctx, mcputil.ToolRunner(s.ResourceMgr, r.Header))
 
 	var data map[string]any
 	if err = util.DecodeJSON(r.Body, &data); err != nil {
@@ -198,7 +220,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	params, err := parameters.ParseParams(tool.GetParameters(), data, claimsFromAuth)
+	params, err := parameters.ParseParamsWithHeaders(tool.GetParameters(), data, claimsFromAuth, r.Header)
 	if err != nil {
 		var clientServerErr *util.ClientServerError
 
@@ -209,6 +231,13 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// Return 400 for parameters missing from the request headers
+		if errors.As(err, &clientServerErr) && clientServerErr.Code == http.StatusBadRequest {
+			s.logger.DebugContext(ctx, fmt.Sprintf("invalid request: %v", err))
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+
 		var agentErr *util.AgentError
 		if errors.As(err, &agentErr) {
 			s.logger.DebugContext(ctx, fmt.Sprintf("agent validation error: %v", err))
@@ -262,7 +291,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 				// Process auth error
 				if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
-					if clientAuth {
+					if clientAuth || clientServerErr.Caller {
 						// Token error, pass through 401/403
 						s.logger.DebugContext(ctx, fmt.Sprintf("Client credentials lack authorization: %v", err))
 						_ = render.Render(w, r, newErrResponse(err, statusCode))
@@ -284,6 +313,12 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// results formatted by the `output` of the tool are sent as is
+	if formatted, ok := res.(tools.FormattedResult); ok {
+		_ = render.Render(w, r, &resultResponse{Result: string(formatted)})
+		return
+	}
+
 	resMarshal, err := json.Marshal(res)
 	if err != nil {
 		err = fmt.Errorf("unable to marshal result: %w", err)
@@ -295,6 +330,155 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal)})
 }
 
+// promptsetHandler handles the request for information about a Promptset.
+func promptsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/promptset/get")
+	r = r.WithContext(ctx)
+
+	promptsetName := chi.URLParam(r, "promptsetName")
+	s.logger.DebugContext(ctx, fmt.Sprintf("promptset name: %s", promptsetName))
+	span.SetAttributes(attribute.String("promptset.name", promptsetName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	promptset, ok := s.ResourceMgr.GetPromptset(promptsetName)
+	if !ok {
+		err = fmt.Errorf("promptset %q does not exist", promptsetName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	render.JSON(w, r, promptset.Manifest)
+}
+
+// promptGetHandler handles requests for a single Prompt.
+func promptGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/prompt/get")
+	r = r.WithContext(ctx)
+
+	promptName := chi.URLParam(r, "promptName")
+	s.logger.DebugContext(ctx, fmt.Sprintf("prompt name: %s", promptName))
+	span.SetAttributes(attribute.String("prompt_name", promptName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	prompt, ok := s.ResourceMgr.GetPrompt(promptName)
+	if !ok {
+		err = fmt.Errorf("invalid prompt name: prompt with name %q does not exist", promptName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	m := prompts.PromptsetManifest{
+		ServerVersion: s.version,
+		PromptsManifest: map[string]prompts.Manifest{
+			promptName: prompt.Manifest(),
+		},
+	}
+
+	render.JSON(w, r, m)
+}
+
+// promptResponse is the response sent back when a prompt was rendered
+// successfully.
+type promptResponse struct {
+	Description string                    `json:"description,omitempty"`
+	Messages    []prompts.RenderedMessage `json:"messages"`
+}
+
+// promptRenderHandler handles the API request to render the messages of a
+// specific Prompt with the arguments in the request body.
+func promptRenderHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/prompt/render")
+	r = r.WithContext(ctx)
+	ctx = util.WithLogger(r.Context(), s.logger)
+
+	promptName := chi.URLParam(r, "promptName")
+	s.logger.DebugContext(ctx, fmt.Sprintf("prompt name: %s", promptName))
+	span.SetAttributes(attribute.String("prompt_name", promptName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	prompt, ok := s.ResourceMgr.GetPrompt(promptName)
+	if !ok {
+		err = fmt.Errorf("invalid prompt name: prompt with name %q does not exist", promptName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+
+	data := make(map[string]any)
+	if r.ContentLength != 0 {
+		if err = util.DecodeJSON(r.Body, &data); err != nil {
+			err = fmt.Errorf("request body was invalid JSON: %w", err)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+	}
+
+	// arguments bound to auth services are taken from the verified claims of
+	// the caller
+	claimsFromAuth := auth.ClaimsFromRequest(ctx, s.ResourceMgr.GetAuthServiceMap(), r.Header)
+	if tbErr := prompts.Authorize(prompt, claimsFromAuth); tbErr != nil {
+		err = tbErr
+		s.logger.DebugContext(ctx, fmt.Sprintf("auth error: %v", err))
+		status := http.StatusForbidden
+		var clientServerErr *util.ClientServerError
+		if errors.As(err, &clientServerErr) {
+			status = clientServerErr.Code
+		}
+		_ = render.Render(w, r, newErrResponse(err, status))
+		return
+	}
+	argValues, err := prompt.ParseArgs(data, claimsFromAuth)
+	if err != nil {
+		var clientServerErr *util.ClientServerError
+		if errors.As(err, &clientServerErr) && clientServerErr.Code == http.StatusUnauthorized {
+			s.logger.DebugContext(ctx, fmt.Sprintf("auth error: %v", err))
+			_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+			return
+		}
+		err = fmt.Errorf("invalid arguments for prompt %q: %w", promptName, err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	s.logger.DebugContext(ctx, fmt.Sprintf("parsed args: %v", argValues))
+
+	messages, err := prompts.GetMessages(ctx, prompt, s.ResourceMgr, argValues, mcputil.PromptToolRunner(s.ResourceMgr, r.Header))
+	if err != nil {
+		err = fmt.Errorf("error rendering prompt %q: %w", promptName, err)
+		s.logger.ErrorContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := promptResponse{
+		Description: prompt.Manifest().Description,
+		Messages:    make([]prompts.RenderedMessage, len(messages)),
+	}
+	for i, msg := range messages {
+		res.Messages[i] = msg.Rendered()
+	}
+	render.JSON(w, r, res)
+}
+
 var _ render.Renderer = &resultResponse{} // Renderer interface for managing response payloads.
 
 // resultResponse is the response sent back when the tool was invocated successfully.
@@ -16,14 +16,29 @@ package server
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
+	"github.com/googleapis/genai-toolbox/internal/auth/tokenexchange"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	httptool "github.com/googleapis/genai-toolbox/internal/tools/http"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestToolsetEndpoint(t *testing.T) {
@@ -295,3 +310,392 @@ func TestToolInvokeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestToolsetPolicies(t *testing.T) {
+	toolsMap, _, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1})
+	toolsetConfigs := map[string]tools.ToolsetConfig{
+		"": {Name: "", ToolNames: []string{tool1.Name, tool2.Name}},
+		"restricted": {
+			Name:      "restricted",
+			ToolNames: []string{tool1.Name},
+			Policies:  tools.Policies{{Claim: "email", EndsWith: "@example.com"}},
+		},
+	}
+	tools.ApplyToolsetPolicies(toolsMap, toolsetConfigs)
+	toolsets := make(map[string]tools.Toolset)
+	for name, tc := range toolsetConfigs {
+		ts, err := tc.Initialize(fakeVersionString, toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset %q: %s", name, err)
+		}
+		toolsets[name] = ts
+	}
+
+	testCases := []struct {
+		name   string
+		router string
+		path   string
+		body   string
+		isErr  bool
+	}{
+		{name: "api tool of restricted toolset", router: "api", path: "/tool/no_params/invoke", body: `{}`, isErr: true},
+		{name: "mcp default toolset", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/call", "params": {"name": "no_params"}}`, isErr: true},
+		{name: "mcp default toolset other tool", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/call", "params": {"name": "some_params", "arguments": {"param1": 1, "param2": 2}}}`},
+		{name: "mcp default toolset list", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/list"}`},
+		{name: "mcp restricted toolset", router: "mcp", path: "/restricted", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/call", "params": {"name": "no_params"}}`, isErr: true},
+		{name: "mcp restricted toolset list", router: "mcp", path: "/restricted", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/list"}`, isErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, shutdown := setUpServer(t, tc.router, toolsMap, toolsets, promptsMap, promptsets)
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, strings.NewReader(tc.body), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if !tc.isErr {
+				if resp.StatusCode != http.StatusOK || strings.Contains(string(body), `"error"`) {
+					t.Fatalf("expected success, got %d: %s", resp.StatusCode, string(body))
+				}
+				// the tools of the restricted toolset are hidden elsewhere
+				if strings.Contains(string(body), tool1.Name) {
+					t.Fatalf("expected %q to be hidden, got %s", tool1.Name, string(body))
+				}
+				return
+			}
+			if resp.StatusCode != http.StatusForbidden {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusForbidden, resp.StatusCode, string(body))
+			}
+			if !strings.Contains(string(body), "forbidden") {
+				t.Fatalf("expected forbidden error, got %s", string(body))
+			}
+		})
+	}
+
+	t.Run("tool runner", func(t *testing.T) {
+		ctx, err := testutils.ContextWithNewLogger()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resourceMgr := resources.NewResourceManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+		run := mcputil.ToolRunner(resourceMgr, http.Header{})
+		if _, err := run(tools.WithToolset(ctx, toolsets[""]), tool2.Name, map[string]any{"param1": 1, "param2": 2}); err != nil {
+			t.Fatalf("unexpected error from the default toolset: %s", err)
+		}
+		for _, name := range []string{"", "restricted"} {
+			_, err = run(tools.WithToolset(ctx, toolsets[name]), tool1.Name, nil)
+			if err == nil || !strings.Contains(err.Error(), "forbidden") {
+				t.Fatalf("expected forbidden error from toolset %q, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestTokenExchangeStatus(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tokenEndpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("subject_token") != "good-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "downstream-token", "expires_in": 3600})
+	}))
+	defer tokenEndpoint.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer downstream-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`"ok"`))
+	}))
+	defer api.Close()
+
+	// API keys are the verified tokens exchanged by the source
+	hash := func(key string) string {
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+	authService, err := apikey.Config{
+		Name: "my-auth",
+		Type: "api-key",
+		Keys: []apikey.Key{{Id: "good", Hash: hash("good-key")}, {Id: "revoked", Hash: hash("revoked-key")}},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+	src, err := httpsrc.Config{
+		Name:          "my-http",
+		Type:          "http",
+		BaseURL:       api.URL,
+		Timeout:       "10s",
+		TokenExchange: &tokenexchange.Config{TokenEndpoint: tokenEndpoint.URL, AuthService: "my-auth"},
+	}.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	sourcesMap := map[string]sources.Source{"my-http": src}
+	tool, err := httptool.Config{
+		Name:        "my-http-tool",
+		Type:        "http",
+		Source:      "my-http",
+		Description: "d",
+		Path:        "/",
+		Method:      http.MethodGet,
+	}.Initialize(sourcesMap)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	toolsMap := map[string]tools.Tool{"my-http-tool": tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"my-http-tool"}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	promptset, err := prompts.PromptsetConfig{Name: ""}.Initialize(fakeVersionString, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize promptset: %s", err)
+	}
+	resourceMgr := resources.NewResourceManager(sourcesMap, map[string]auth.AuthService{"my-auth": authService}, nil, toolsMap, map[string]tools.Toolset{"": toolset}, nil, map[string]prompts.Promptset{"": promptset})
+
+	testCases := []struct {
+		name   string
+		router string
+		path   string
+		body   string
+		key    string
+		want   int
+	}{
+		{name: "api exchanged token", router: "api", path: "/tool/my-http-tool/invoke", body: `{}`, key: "good-key", want: http.StatusOK},
+		{name: "api missing subject token", router: "api", path: "/tool/my-http-tool/invoke", body: `{}`, want: http.StatusUnauthorized},
+		{name: "api rejected subject token", router: "api", path: "/tool/my-http-tool/invoke", body: `{}`, key: "revoked-key", want: http.StatusForbidden},
+		{name: "mcp missing subject token", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/call", "params": {"name": "my-http-tool"}}`, want: http.StatusUnauthorized},
+		{name: "mcp rejected subject token", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "tools/call", "params": {"name": "my-http-tool"}}`, key: "revoked-key", want: http.StatusForbidden},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, shutdown := setUpServerWithResources(t, tc.router, resourceMgr)
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			header := map[string]string{}
+			if tc.key != "" {
+				header["my-auth_token"] = tc.key
+			}
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, strings.NewReader(tc.body), header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Fatalf("expected status %d, got %d: %s", tc.want, resp.StatusCode, string(body))
+			}
+		})
+	}
+}
+
+func TestPromptAuthorization(t *testing.T) {
+	toolsMap, toolsets, promptsMap, _ := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{prompt1})
+	promptsMap["restricted_prompt"] = MockAuthorizedPrompt{
+		MockPrompt: MockPrompt{Name: "restricted_prompt", Args: prompts.Arguments{}},
+		Policies:   tools.Policies{{Claim: "email", EndsWith: "@example.com"}},
+	}
+	psc := prompts.PromptsetConfig{Name: "", PromptNames: []string{prompt1.Name, "restricted_prompt"}}
+	ps, err := psc.Initialize(fakeVersionString, promptsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize default promptset: %s", err)
+	}
+	promptsets := map[string]prompts.Promptset{"": ps}
+
+	testCases := []struct {
+		name   string
+		router string
+		path   string
+		body   string
+		isErr  bool
+	}{
+		{name: "api restricted prompt", router: "api", path: "/prompt/restricted_prompt/get", body: `{}`, isErr: true},
+		{name: "api unrestricted prompt", router: "api", path: "/prompt/prompt1/get", body: `{}`},
+		{name: "mcp restricted prompt", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "prompts/get", "params": {"name": "restricted_prompt"}}`, isErr: true},
+		{name: "mcp unrestricted prompt", router: "mcp", path: "/", body: `{"jsonrpc": "2.0", "id": "1", "method": "prompts/get", "params": {"name": "prompt1"}}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, shutdown := setUpServer(t, tc.router, toolsMap, toolsets, promptsMap, promptsets)
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, strings.NewReader(tc.body), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if !tc.isErr {
+				if resp.StatusCode != http.StatusOK || strings.Contains(string(body), `"error"`) {
+					t.Fatalf("expected success, got %d: %s", resp.StatusCode, string(body))
+				}
+				// the tools of the restricted toolset are hidden elsewhere
+				if strings.Contains(string(body), tool1.Name) {
+					t.Fatalf("expected %q to be hidden, got %s", tool1.Name, string(body))
+				}
+				return
+			}
+			if resp.StatusCode != http.StatusForbidden {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusForbidden, resp.StatusCode, string(body))
+			}
+			if !strings.Contains(string(body), "forbidden") {
+				t.Fatalf("expected forbidden error, got %s", string(body))
+			}
+		})
+	}
+}
+
+func TestPromptAuthenticatedArguments(t *testing.T) {
+	sum := sha256.Sum256([]byte("alice-key"))
+	authService, err := apikey.Config{
+		Name: "my-auth",
+		Type: "api-key",
+		Keys: []apikey.Key{{Id: "alice", Hash: hex.EncodeToString(sum[:]), Claims: map[string]any{"email": "alice@example.com"}}},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+	authPrompt := MockPrompt{
+		Name: "auth_prompt",
+		Args: prompts.Arguments{
+			{Parameter: parameters.NewStringParameterWithAuth("email", "The email of the caller.", []parameters.ParamAuthService{{Name: "my-auth", Field: "email"}})},
+		},
+	}
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, []MockTool{tool1, tool2}, []MockPrompt{authPrompt})
+	resourceMgr := resources.NewResourceManager(nil, map[string]auth.AuthService{"my-auth": authService}, nil, toolsMap, toolsets, promptsMap, promptsets)
+
+	mcpBody := `{"jsonrpc": "2.0", "id": "1", "method": "prompts/get", "params": {"name": "auth_prompt"}}`
+	testCases := []struct {
+		name   string
+		router string
+		path   string
+		body   string
+		header map[string]string
+		isErr  bool
+	}{
+		{name: "api with token", router: "api", path: "/prompt/auth_prompt/get", body: `{}`, header: map[string]string{"my-auth_token": "alice-key"}},
+		{name: "api without token", router: "api", path: "/prompt/auth_prompt/get", body: `{}`, isErr: true},
+		{name: "mcp 2025-03-26 with token", router: "mcp", path: "/", body: mcpBody, header: map[string]string{"Mcp-Session-Id": "session", "my-auth_token": "alice-key"}},
+		{name: "mcp 2025-03-26 without token", router: "mcp", path: "/", body: mcpBody, header: map[string]string{"Mcp-Session-Id": "session"}, isErr: true},
+		{name: "mcp 2025-06-18 with token", router: "mcp", path: "/", body: mcpBody, header: map[string]string{"MCP-Protocol-Version": protocolVersion20250618, "my-auth_token": "alice-key"}},
+		{name: "mcp 2025-06-18 without token", router: "mcp", path: "/", body: mcpBody, header: map[string]string{"MCP-Protocol-Version": protocolVersion20250618}, isErr: true},
+		{name: "mcp 2025-11-25 with token", router: "mcp", path: "/", body: mcpBody, header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125, "my-auth_token": "alice-key"}},
+		{name: "mcp 2025-11-25 without token", router: "mcp", path: "/", body: mcpBody, header: map[string]string{"MCP-Protocol-Version": protocolVersion20251125}, isErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, shutdown := setUpServerWithResources(t, tc.router, resourceMgr)
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, strings.NewReader(tc.body), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			failed := resp.StatusCode != http.StatusOK || strings.Contains(string(body), `"error"`)
+			if failed != tc.isErr {
+				t.Fatalf("expected error %t, got %d: %s", tc.isErr, resp.StatusCode, string(body))
+			}
+		})
+	}
+}
+
+func TestPromptEndpoints(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	mockPrompts := []MockPrompt{prompt1, prompt2}
+	toolsMap, toolsets, promptsMap, promptsets := setUpResources(t, mockTools, mockPrompts)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, promptsMap, promptsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "default promptset",
+			method:         http.MethodGet,
+			url:            "/promptset",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"prompt2":{"description":"","arguments":[{"name":"arg1"`,
+		},
+		{
+			name:           "invalid promptset name",
+			method:         http.MethodGet,
+			url:            "/promptset/some_imaginary_promptset",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "get prompt",
+			method:         http.MethodGet,
+			url:            "/prompt/prompt1",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"serverVersion":"0.0.0","prompts":{"prompt1":{"description":"","arguments":null}}}`,
+		},
+		{
+			name:           "invalid prompt name",
+			method:         http.MethodGet,
+			url:            "/prompt/some_imaginary_prompt",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "render prompt",
+			method:         http.MethodPost,
+			url:            "/prompt/prompt2/get",
+			body:           `{"arg1": "value"}`,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"messages":[{"role":"user","content":{"text":"substituted prompt2","type":"text"}}]}`,
+		},
+		{
+			name:           "render prompt without arguments",
+			method:         http.MethodPost,
+			url:            "/prompt/prompt1/get",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"messages":[{"role":"user","content":{"text":"substituted prompt1","type":"text"}}]}`,
+		},
+		{
+			name:           "missing argument",
+			method:         http.MethodPost,
+			url:            "/prompt/prompt2/get",
+			body:           `{}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `parameter \"arg1\" is required`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			resp, got, err := runRequest(ts, tc.method, tc.url, body, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatusCode {
+				t.Fatalf("unexpected status code: want %d, got %d: %s", tc.wantStatusCode, resp.StatusCode, got)
+			}
+			if !strings.Contains(string(got), tc.wantBody) {
+				t.Fatalf("unexpected response body: want to contain %s, got %s", tc.wantBody, got)
+			}
+		})
+	}
+}
@@ -131,6 +131,13 @@ func setUpResources(t *testing.T, mockTools []MockTool, mockPrompts []MockPrompt
 
 // setUpServer create a new server with tools, toolsets, prompts, and promptsets.
 func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, prompts map[string]prompts.Prompt, promptsets map[string]prompts.Promptset) (chi.Router, func()) {
+	resourceManager := resources.NewResourceManager(nil, nil, nil, tools, toolsets, prompts, promptsets)
+	return setUpServerWithResources(t, router, resourceManager)
+}
+
+// setUpServerWithResources sets up a server serving the resources of the
+// resource manager, such as sources used by real tools.
+func setUpServerWithResources(t *testing.T, router string, resourceManager *resources.ResourceManager) (chi.Router, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
@@ -150,14 +157,14 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 
 	sseManager := newSseManager(ctx)
 
-	resourceManager := resources.NewResourceManager(nil, nil, nil, tools, toolsets, prompts, promptsets)
-
 	server := Server{
 		version:         fakeVersionString,
 		logger:          testLogger,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
 		ResourceMgr:     resourceManager,
+
+		elicitationManager: newElicitationManager(ctx),
 	}
 
 	var r chi.Router
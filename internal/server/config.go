@@ -23,10 +23,13 @@ import (
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
 	"github.com/googleapis/genai-toolbox/internal/auth/generic"
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/auth/introspection"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/gemini"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels/openai"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -278,6 +281,18 @@ func UnmarshalYAMLAuthServiceConfig(ctx context.Context, name string, r map[stri
 			return nil, fmt.Errorf("unable to parse as %s: %w", name, err)
 		}
 		return actual, nil
+	case apikey.AuthServiceType:
+		actual := apikey.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %s: %w", name, err)
+		}
+		return actual, nil
+	case introspection.AuthServiceType:
+		actual := introspection.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %s: %w", name, err)
+		}
+		return actual, nil
 	default:
 		return nil, fmt.Errorf("%s is not a valid type of auth service", resourceType)
 	}
@@ -288,18 +303,67 @@ func UnmarshalYAMLEmbeddingModelConfig(ctx context.Context, name string, r map[s
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
-	if resourceType != gemini.EmbeddingModelType {
-		return nil, fmt.Errorf("%s is not a valid type of embedding model", resourceType)
+
+	// `cache` and `batch` are supported by every embedding model type, so
+	// they are decoded here rather than by the model's config
+	var cache *embeddingmodels.CacheOptions
+	if rawCache, ok := r["cache"]; ok {
+		delete(r, "cache")
+		cache = &embeddingmodels.CacheOptions{}
+		if err := decodeEmbeddingModelOptions(ctx, rawCache, cache); err != nil {
+			return nil, fmt.Errorf("unable to parse cache of embedding model %q: %w", name, err)
+		}
+	}
+	var batch *embeddingmodels.BatchOptions
+	if rawBatch, ok := r["batch"]; ok {
+		delete(r, "batch")
+		batch = &embeddingmodels.BatchOptions{}
+		if err := decodeEmbeddingModelOptions(ctx, rawBatch, batch); err != nil {
+			return nil, fmt.Errorf("unable to parse batch of embedding model %q: %w", name, err)
+		}
 	}
+
 	dec, err := util.NewStrictDecoder(r)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
-	actual := gemini.Config{Name: name}
-	if err := dec.DecodeContext(ctx, &actual); err != nil {
-		return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+
+	var modelCfg embeddingmodels.EmbeddingModelConfig
+	switch resourceType {
+	case gemini.EmbeddingModelType:
+		actual := gemini.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+		}
+		modelCfg = actual
+	case openai.EmbeddingModelType:
+		actual := openai.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+		}
+		modelCfg = actual
+	default:
+		return nil, fmt.Errorf("%s is not a valid type of embedding model", resourceType)
+	}
+	// the cache is the outermost wrapper, so that only missing texts are
+	// batched
+	if batch != nil {
+		modelCfg = embeddingmodels.BatchedConfig{EmbeddingModelConfig: modelCfg, Batch: *batch}
+	}
+	if cache != nil {
+		modelCfg = embeddingmodels.CachedConfig{EmbeddingModelConfig: modelCfg, Name: name, Cache: *cache}
+	}
+	return modelCfg, nil
+}
+
+// decodeEmbeddingModelOptions strictly decodes the options shared by every
+// embedding model type.
+func decodeEmbeddingModelOptions(ctx context.Context, raw any, v any) error {
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return fmt.Errorf("error creating decoder: %s", err)
 	}
-	return actual, nil
+	return dec.DecodeContext(ctx, v)
 }
 
 func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any) (tools.ToolConfig, error) {
@@ -354,6 +418,57 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 		}
 	}
 
+	// `constraints` are supported by every tool type, so they are decoded
+	// here rather than by the tool's factory
+	var constraints tools.Constraints
+	if rawConstraints, ok := r["constraints"]; ok {
+		delete(r, "constraints")
+		dec, err := util.NewStrictDecoder(rawConstraints)
+		if err != nil {
+			return nil, fmt.Errorf("error creating decoder: %s", err)
+		}
+		if err := dec.DecodeContext(ctx, &constraints); err != nil {
+			return nil, fmt.Errorf("unable to parse constraints of tool %q: %w", name, err)
+		}
+	}
+
+	// `policies` are supported by every tool type as well
+	var policies tools.Policies
+	if rawPolicies, ok := r["policies"]; ok {
+		delete(r, "policies")
+		dec, err := util.NewStrictDecoder(rawPolicies)
+		if err != nil {
+			return nil, fmt.Errorf("error creating decoder: %s", err)
+		}
+		if err := dec.DecodeContext(ctx, &policies); err != nil {
+			return nil, fmt.Errorf("unable to parse policies of tool %q: %w", name, err)
+		}
+	}
+
+	// `requireConfirmation` is supported by every tool type as well
+	var requireConfirmation bool
+	if rawConfirmation, ok := r["requireConfirmation"]; ok {
+		delete(r, "requireConfirmation")
+		requireConfirmation, ok = rawConfirmation.(bool)
+		if !ok {
+			return nil, fmt.Errorf("requireConfirmation of tool %q must be a boolean", name)
+		}
+	}
+
+	// `output` is supported by every tool type as well
+	var output *tools.Output
+	if rawOutput, ok := r["output"]; ok {
+		delete(r, "output")
+		dec, err := util.NewStrictDecoder(rawOutput)
+		if err != nil {
+			return nil, fmt.Errorf("error creating decoder: %s", err)
+		}
+		output = &tools.Output{}
+		if err := dec.DecodeContext(ctx, output); err != nil {
+			return nil, fmt.Errorf("unable to parse output of tool %q: %w", name, err)
+		}
+	}
+
 	dec, err := util.NewStrictDecoder(r)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
@@ -362,6 +477,21 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 	if err != nil {
 		return nil, err
 	}
+	if output != nil {
+		toolCfg = tools.OutputConfig{ToolConfig: toolCfg, Output: *output}
+	}
+	// confirmation is within the constraints, so that the user is only asked
+	// once the constraints are satisfied
+	if requireConfirmation {
+		toolCfg = tools.ConfirmationConfig{ToolConfig: toolCfg}
+	}
+	if len(constraints) > 0 {
+		toolCfg = tools.ConstrainedConfig{ToolConfig: toolCfg, Constraints: constraints}
+	}
+	// policies must be the outermost wrapper, see tools.PolicyConfig
+	if len(policies) > 0 {
+		toolCfg = tools.PolicyConfig{ToolConfig: toolCfg, Policies: policies}
+	}
 	return toolCfg, nil
 }
 
@@ -380,7 +510,17 @@ func UnmarshalYAMLToolsetConfig(ctx context.Context, name string, r map[string]a
 	if err := dec.DecodeContext(ctx, &raw); err != nil {
 		return toolsetConfig, fmt.Errorf("unable to unmarshal tools: %s", err)
 	}
-	return tools.ToolsetConfig{Name: name, ToolNames: raw["tools"]}, nil
+	var policies tools.Policies
+	if rawPolicies, ok := r["policies"]; ok {
+		dec, err := util.NewStrictDecoder(rawPolicies)
+		if err != nil {
+			return toolsetConfig, fmt.Errorf("error creating decoder: %s", err)
+		}
+		if err := dec.DecodeContext(ctx, &policies); err != nil {
+			return toolsetConfig, fmt.Errorf("unable to parse policies of toolset %q: %w", name, err)
+		}
+	}
+	return tools.ToolsetConfig{Name: name, ToolNames: raw["tools"], Policies: policies}, nil
 }
 
 func UnmarshalYAMLPromptConfig(ctx context.Context, name string, r map[string]any) (prompts.PromptConfig, error) {
@@ -30,12 +30,14 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
-	"github.com/googleapis/genai-toolbox/internal/auth/generic"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
 	v20250326 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250326"
+	v20250618 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250618"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -124,6 +126,26 @@ type stdioSession struct {
 	server   *Server
 	reader   *bufio.Reader
 	writer   io.Writer
+	// elicitation is true if the client supports elicitation
+	elicitation bool
+	// queued holds the messages read while waiting for an elicitation
+	// response
+	queued []string
+	// reads asks the reader goroutine of the session to read a line, which
+	// is sent on lines
+	reads chan struct{}
+	lines chan stdioLine
+	// reading is true if a line was requested but not received yet, e.g.
+	// when a read is cancelled
+	reading bool
+	// readErr is the error ending the input stream
+	readErr error
+}
+
+// stdioLine is a line read from the input stream.
+type stdioLine struct {
+	line string
+	err  error
 }
 
 // traceContextCarrier implements propagation.TextMapCarrier for extracting trace context from _meta
@@ -180,10 +202,26 @@ func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession
 		server: s,
 		reader: bufio.NewReader(stdin),
 		writer: stdout,
+		reads:  make(chan struct{}, 1),
+		lines:  make(chan stdioLine, 1),
 	}
+	go stdioSession.readRoutine()
 	return stdioSession
 }
 
+// readRoutine reads a line each time one is requested. It is the only
+// reader of the input stream, so a read cancelled by readLine isn't lost,
+// but received by the next call.
+func (s *stdioSession) readRoutine() {
+	for range s.reads {
+		line, err := s.reader.ReadString('\n')
+		s.lines <- stdioLine{line: line, err: err}
+		if err != nil {
+			return
+		}
+	}
+}
+
 func (s *stdioSession) Start(ctx context.Context) error {
 	return s.readInputStream(ctx)
 }
@@ -246,6 +284,10 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 			)
 			defer span.End()
 
+			if s.elicitation {
+				msgCtx = tools.WithElicitor(msgCtx, s)
+			}
+
 			var v string
 			var res any
 			v, res, err = processMcpMessage(msgCtx, []byte(line), s.server, s.protocol, "", "", nil, "")
@@ -258,6 +300,7 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 
 			if v != "" {
 				s.protocol = v
+				s.elicitation = clientSupportsElicitation([]byte(line), v)
 			}
 			// no responses for notifications
 			if res != nil {
@@ -274,44 +317,32 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 
 // readLine process each line within the input stream.
 func (s *stdioSession) readLine(ctx context.Context) (string, error) {
-	readChan := make(chan string, 1)
-	errChan := make(chan error, 1)
-	done := make(chan struct{})
-	defer close(done)
-	defer close(readChan)
-	defer close(errChan)
-
-	go func() {
-		select {
-		case <-done:
-			return
-		default:
-			line, err := s.reader.ReadString('\n')
-			if err != nil {
-				select {
-				case errChan <- err:
-				case <-done:
-				}
-				return
-			}
-			select {
-			case readChan <- line:
-			case <-done:
-			}
-			return
-		}
-	}()
+	if len(s.queued) > 0 {
+		line := s.queued[0]
+		s.queued = s.queued[1:]
+		return line, nil
+	}
+	if s.readErr != nil {
+		return "", s.readErr
+	}
 
+	if !s.reading {
+		s.reads <- struct{}{}
+		s.reading = true
+	}
 	select {
 	// if context is cancelled, return an empty string
 	case <-ctx.Done():
 		return "", ctx.Err()
-	// return error if error is found
-	case err := <-errChan:
-		return "", err
-	// return line if successful
-	case line := <-readChan:
-		return line, nil
+	case l := <-s.lines:
+		s.reading = false
+		// return error if error is found
+		if l.err != nil {
+			s.readErr = l.err
+			return "", l.err
+		}
+		// return line if successful
+		return l.line, nil
 	}
 }
 
@@ -338,13 +369,13 @@ func mcpRouter(s *Server) (chi.Router, error) {
 	r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
 	r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-	r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+	r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, r) })
 
 	r.Route("/{toolsetName}", func(r chi.Router) {
 		r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
 		r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, r) })
 	})
 
 	return r, nil
@@ -451,6 +482,11 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// deleteSessionHandler terminates the session of the client.
+func deleteSessionHandler(s *Server, r *http.Request) {
+	s.elicitationManager.removeSession(r.Header.Get("Mcp-Session-Id"))
+}
+
 // methodNotAllowed handles all mcp messages.
 func methodNotAllowed(s *Server, w http.ResponseWriter, r *http.Request) {
 	err := fmt.Errorf("toolbox does not support streaming in streamable HTTP transport")
@@ -527,6 +563,20 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
 	span.SetAttributes(attribute.String("toolset.name", toolsetName))
 
+	// responses to elicitation requests resume the pending tool invocation
+	if s.elicitationManager.deliver(body, headerSessionId) {
+		span.End()
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	// elicitation is sent on an SSE stream, which requires v2025-06-18+
+	var elicitor *httpElicitor
+	if protocolVersion >= v20250618.PROTOCOL_VERSION && acceptsEventStream(r.Header) && s.elicitationManager.hasSession(headerSessionId) {
+		elicitor = &httpElicitor{manager: s.elicitationManager, sessionId: headerSessionId, w: w}
+		ctx = tools.WithElicitor(ctx, elicitor)
+	}
+
 	defer func() {
 		if err != nil {
 			span.SetStatus(codes.Error, err.Error())
@@ -554,6 +604,21 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		sessionId = uuid.New().String()
 		w.Header().Set("Mcp-Session-Id", sessionId)
 	}
+	// clients supporting elicitation get a session, so that it is known for
+	// the following requests
+	if s.elicitationManager != nil && clientSupportsElicitation(body, v) {
+		sessionId = s.elicitationManager.addSession()
+		w.Header().Set("Mcp-Session-Id", sessionId)
+	}
+
+	// the response was switched to an SSE stream to elicit input from the
+	// user, so the result is sent on it as well
+	if elicitor != nil && elicitor.isStreaming() {
+		if err := elicitor.send(res); err != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("unable to send result: %s", err))
+		}
+		return
+	}
 
 	if session != nil {
 		// queue sse event
@@ -775,11 +840,11 @@ func prmHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	scopes := []string{}
 	for _, authSvc := range s.ResourceMgr.GetAuthServiceMap() {
 		cfg := authSvc.ToConfig()
-		if genCfg, ok := cfg.(generic.Config); ok {
-			if genCfg.McpEnabled {
-				server = genCfg.AuthorizationServer
-				if genCfg.ScopesRequired != nil {
-					scopes = genCfg.ScopesRequired
+		if mcpCfg, ok := cfg.(auth.MCPAuthServiceConfig); ok {
+			if mcpCfg.IsMcpEnabled() {
+				server = mcpCfg.GetAuthorizationServer()
+				if mcpCfg.GetScopesRequired() != nil {
+					scopes = mcpCfg.GetScopesRequired()
 				}
 				break
 			}
@@ -23,6 +23,9 @@ const (
 	SERVER_NAME = "Toolbox"
 	// methods that are supported
 	INITIALIZE = "initialize"
+	// ELICITATION_CREATE is sent by the server to request input from the
+	// user. Supported since v2025-06-18.
+	ELICITATION_CREATE = "elicitation/create"
 )
 
 /* Initialization */
@@ -82,6 +85,8 @@ type ClientCapabilities struct {
 	Roots *ListChanged `json:"roots,omitempty"`
 	// Present if the client supports sampling from an LLM.
 	Sampling struct{} `json:"sampling,omitempty"`
+	// Present if the client supports elicitation from the server.
+	Elicitation *struct{} `json:"elicitation,omitempty"`
 }
 
 // ServerCapabilities represents capabilities that a server may support. Known
@@ -111,3 +116,13 @@ type Implementation struct {
 	BaseMetadata
 	Version string `json:"version"`
 }
+
+/* Elicitation */
+
+// ElicitRequestParams are the params of the `elicitation/create` request.
+type ElicitRequestParams struct {
+	// The message to present to the user.
+	Message string `json:"message"`
+	// A restricted subset of JSON Schema describing the requested input.
+	RequestedSchema map[string]any `json:"requestedSchema"`
+}
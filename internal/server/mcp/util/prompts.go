@@ -0,0 +1,29 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"net/http"
+
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+)
+
+// PromptToolRunner returns the runner of the tools of prompt messages. The
+// tools are run on behalf of the caller of `prompts/get`, with the same
+// authorization checks as a `tools/call` request.
+func PromptToolRunner(resourceMgr *resources.ResourceManager, header http.Header) prompts.ToolRunner {
+	return prompts.ToolRunner(ToolRunner(resourceMgr, header))
+}
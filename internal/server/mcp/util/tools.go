@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/server/resources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// ToolRunner returns the runner of the tools called on behalf of the caller of
+// a request, such as the tools of prompt messages or the steps of composite
+// tools. They are run with the same authorization checks as a `tools/call`
+// request.
+func ToolRunner(resourceMgr *resources.ResourceManager, header http.Header) tools.ToolRunner {
+	var run tools.ToolRunner
+	run = func(ctx context.Context, name string, args map[string]any) (any, error) {
+		tool, ok := resourceMgr.GetTool(name)
+		if !ok {
+			return nil, fmt.Errorf("tool with name %q does not exist", name)
+		}
+
+		authTokenHeadername, err := tool.GetAuthTokenHeaderName(resourceMgr)
+		if err != nil {
+			return nil, err
+		}
+		accessToken := tools.AccessToken(header.Get(authTokenHeadername))
+		clientAuth, err := tool.RequiresClientAuthorization(resourceMgr)
+		if err != nil {
+			return nil, err
+		}
+		if clientAuth && accessToken == "" {
+			return nil, util.NewClientServerError("missing access token in the 'Authorization' header", http.StatusUnauthorized, nil)
+		}
+
+		claimsFromAuth := auth.ClaimsFromRequest(ctx, resourceMgr.GetAuthServiceMap(), header)
+		verifiedAuthServices := make([]string, 0, len(claimsFromAuth))
+		for k := range claimsFromAuth {
+			verifiedAuthServices = append(verifiedAuthServices, k)
+		}
+		if !tool.Authorized(verifiedAuthServices) {
+			return nil, util.NewClientServerError("unauthorized tool call: please make sure you specify correct auth headers", http.StatusUnauthorized, nil)
+		}
+		if toolset, ok := tools.ToolsetFromContext(ctx); ok {
+			if tbErr := toolset.Policies.Check(claimsFromAuth); tbErr != nil {
+				return nil, tbErr
+			}
+		}
+		if tbErr := tools.CheckPolicies(tool, claimsFromAuth); tbErr != nil {
+			return nil, tbErr
+		}
+		ctx = auth.WithSubjectTokens(ctx, auth.SubjectTokensFromRequest(ctx, claimsFromAuth, header))
+
+		params, err := parameters.ParseParamsWithHeaders(tool.GetParameters(), args, claimsFromAuth, header)
+		if err != nil {
+			return nil, fmt.Errorf("provided parameters were invalid: %w", err)
+		}
+		params, err = tool.EmbedParams(ctx, params, resourceMgr.GetEmbeddingModelMap())
+		if err != nil {
+			return nil, fmt.Errorf("error embedding parameters: %w", err)
+		}
+		ctx = tools.WithToolRunner(ctx, run)
+		res, tbErr := tool.Invoke(ctx, resourceMgr, params, accessToken)
+		if tbErr != nil {
+			return nil, tbErr
+		}
+		return res, nil
+	}
+	return run
+}
@@ -23,8 +23,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
@@ -36,17 +38,20 @@ import (
 
 // ProcessMethod returns a response for the request.
 func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, promptset prompts.Promptset, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
+	// the tools run on behalf of the caller, by composite tools and prompts,
+	// are checked against the policies of the toolset being served
+	ctx = tools.WithToolset(ctx, toolset)
 	switch method {
 	case PING:
 		return pingHandler(id)
 	case TOOLS_LIST:
-		return toolsListHandler(id, toolset, body)
+		return toolsListHandler(ctx, id, toolset, resourceMgr, body, header)
 	case TOOLS_CALL:
-		return toolsCallHandler(ctx, id, resourceMgr, body, header)
+		return toolsCallHandler(ctx, id, toolset, resourceMgr, body, header)
 	case PROMPTS_LIST:
 		return promptsListHandler(ctx, id, promptset, body)
 	case PROMPTS_GET:
-		return promptsGetHandler(ctx, id, resourceMgr, body)
+		return promptsGetHandler(ctx, id, resourceMgr, body, header)
 	default:
 		err := fmt.Errorf("invalid method %s", method)
 		return jsonrpc.NewError(id, jsonrpc.METHOD_NOT_FOUND, err.Error(), nil), err
@@ -62,13 +67,20 @@ func pingHandler(id jsonrpc.RequestId) (any, error) {
 	}, nil
 }
 
-func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte) (any, error) {
+func toolsListHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
 	var req ListToolsRequest
 	if err := json.Unmarshal(body, &req); err != nil {
 		err = fmt.Errorf("invalid mcp tools list request: %w", err)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
 
+	// only list the tools the caller is authorized to use
+	claimsFromAuth := auth.ClaimsFromRequest(ctx, resourceMgr.GetAuthServiceMap(), header)
+	toolset, tbErr := toolset.Authorize(claimsFromAuth)
+	if tbErr != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, tbErr.Error(), nil), tbErr
+	}
+
 	result := ListToolsResult{
 		Tools: toolset.McpManifest,
 	}
@@ -80,7 +92,7 @@ func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte)
 }
 
 // toolsCallHandler generate a response for tools call.
-func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
+func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
 	authServices := resourceMgr.GetAuthServiceMap()
 
 	// retrieve logger from context
@@ -159,23 +171,7 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 
 	// Tool authentication
 	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
-	claimsFromAuth := make(map[string]map[string]any)
-
-	// if using stdio, header will be nil and auth will not be supported
-	if header != nil {
-		for _, aS := range authServices {
-			claims, err := aS.GetClaimsFromHeader(ctx, header)
-			if err != nil {
-				logger.DebugContext(ctx, err.Error())
-				continue
-			}
-			if claims == nil {
-				// authService not present in header
-				continue
-			}
-			claimsFromAuth[aS.GetName()] = claims
-		}
-	}
+	claimsFromAuth := auth.ClaimsFromRequest(ctx, authServices, header)
 
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
@@ -195,9 +191,22 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 		)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
+
+	// Check the authorization policies of the toolset and the tool
+	if tbErr := toolset.Policies.Check(claimsFromAuth); tbErr != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, tbErr.Error(), nil), tbErr
+	}
+	if tbErr := tools.CheckPolicies(tool, claimsFromAuth); tbErr != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, tbErr.Error(), nil), tbErr
+	}
 	logger.DebugContext(ctx, "tool invocation authorized")
+	// make the verified tokens of the caller available to sources exchanging
+	// them for downstream credentials
+	ctx = auth.WithSubjectTokens(ctx, auth.SubjectTokensFromRequest(ctx, claimsFromAuth, header))
+	// let the tools calling other tools run them on behalf of the caller
+	ctx = tools.WithToolRunner(ctx, mcputil.ToolRunner(resourceMgr, header))
 
-	params, err := parameters.ParseParams(tool.GetParameters(), data, claimsFromAuth)
+	params, err := parameters.ParseParamsWithHeaders(tool.GetParameters(), data, claimsFromAuth, header)
 	if err != nil {
 		err = fmt.Errorf("provided parameters were invalid: %w", err)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
@@ -265,7 +274,7 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 
 				if errors.As(err, &clientServerErr) {
 					if clientServerErr.Code == http.StatusUnauthorized || clientServerErr.Code == http.StatusForbidden {
-						if clientAuth {
+						if clientAuth || clientServerErr.Caller {
 							rpcCode = jsonrpc.INVALID_REQUEST
 						} else {
 							rpcCode = jsonrpc.INTERNAL_ERROR
@@ -289,6 +298,12 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *re
 
 	for _, d := range sliceRes {
 		text := TextContent{Type: "text"}
+		if formatted, ok := d.(tools.FormattedResult); ok {
+			// results formatted by the `output` of the tool are sent as is
+			text.Text = string(formatted)
+			content = append(content, text)
+			continue
+		}
 		dM, err := json.Marshal(d)
 		if err != nil {
 			text.Text = fmt.Sprintf("fail to marshal: %s, result: %s", err, d)
@@ -332,7 +347,7 @@ func promptsListHandler(ctx context.Context, id jsonrpc.RequestId, promptset pro
 }
 
 // promptsGetHandler handles the "prompts/get" method.
-func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *resources.ResourceManager, body []byte) (any, error) {
+func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *resources.ResourceManager, body []byte, header http.Header) (any, error) {
 	// retrieve logger from context
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -366,38 +381,34 @@ func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *r
 		genAIAttrs.PromptName = promptName
 	}
 
+	// Check that the caller can get the prompt, for the prompts restricting it
+	claimsFromAuth := auth.ClaimsFromRequest(ctx, resourceMgr.GetAuthServiceMap(), header)
+	if tbErr := prompts.Authorize(prompt, claimsFromAuth); tbErr != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, tbErr.Error(), nil), tbErr
+	}
+
 	// Parse the arguments provided in the request.
-	argValues, err := prompt.ParseArgs(req.Params.Arguments, nil)
+	argValues, err := prompt.ParseArgs(req.Params.Arguments, claimsFromAuth)
 	if err != nil {
 		err = fmt.Errorf("invalid arguments for prompt %q: %w", promptName, err)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("parsed args: %v", argValues))
 
-	// Substitute the argument values into the prompt's messages.
-	substituted, err := prompt.SubstituteParams(argValues)
+	// Render the messages with the argument values.
+	messages, err := prompts.GetMessages(ctx, prompt, resourceMgr, argValues, mcputil.PromptToolRunner(resourceMgr, header))
 	if err != nil {
-		err = fmt.Errorf("error substituting params for prompt %q: %w", promptName, err)
+		err = fmt.Errorf("error rendering prompt %q: %w", promptName, err)
 		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
 	}
-
-	// Cast the result to the expected []prompts.Message type.
-	substitutedMessages, ok := substituted.([]prompts.Message)
-	if !ok {
-		err = fmt.Errorf("internal error: SubstituteParams returned unexpected type")
-		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
-	}
-	logger.DebugContext(ctx, "substituted params successfully")
+	logger.DebugContext(ctx, "rendered messages successfully")
 
 	// Format the response messages into the required structure.
-	promptMessages := make([]PromptMessage, len(substitutedMessages))
-	for i, msg := range substitutedMessages {
+	promptMessages := make([]PromptMessage, len(messages))
+	for i, msg := range messages {
 		promptMessages[i] = PromptMessage{
-			Role: msg.Role,
-			Content: TextContent{
-				Type: "text",
-				Text: msg.Content,
-			},
+			Role:    msg.Role,
+			Content: promptContent(msg),
 		}
 	}
 
@@ -412,3 +423,24 @@ func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, resourceMgr *r
 		Result:  result,
 	}, nil
 }
+
+// promptContent converts the content of a prompt message into a TextContent,
+// ImageContent or EmbeddedResource.
+func promptContent(msg prompts.Message) any {
+	switch {
+	case msg.Image != nil:
+		return ImageContent{Type: "image", Data: msg.Image.Data, MimeType: msg.Image.MimeType}
+	case msg.Resource != nil:
+		return EmbeddedResource{
+			Type: "resource",
+			Resource: ResourceContents{
+				URI:      msg.Resource.URI,
+				MimeType: msg.Resource.MimeType,
+				Text:     msg.Resource.Text,
+				Blob:     msg.Resource.Blob,
+			},
+		}
+	default:
+		return TextContent{Type: "text", Text: msg.Content}
+	}
+}
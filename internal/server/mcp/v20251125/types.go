@@ -120,6 +120,33 @@ type TextContent struct {
 	Text string `json:"text"`
 }
 
+// ImageContent represents an image provided to or from an LLM.
+type ImageContent struct {
+	Annotated
+	Type string `json:"type"`
+	// The base64-encoded image data.
+	Data string `json:"data"`
+	// The MIME type of the image.
+	MimeType string `json:"mimeType"`
+}
+
+// EmbeddedResource represents the contents of a resource, embedded into a
+// prompt or tool call result.
+type EmbeddedResource struct {
+	Annotated
+	Type     string           `json:"type"`
+	Resource ResourceContents `json:"resource"`
+}
+
+// ResourceContents holds either the text or the base64-encoded binary
+// contents of a resource.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
 // The server's response to a tool call.
 //
 // Any errors that originate from the tool SHOULD be reported inside the result
@@ -214,6 +241,7 @@ type GetPromptResult struct {
 
 // Describes a message returned as part of a prompt.
 type PromptMessage struct {
-	Role    string      `json:"role"`
-	Content TextContent `json:"content"`
+	Role string `json:"role"`
+	// Could be either a TextContent, ImageContent, or EmbeddedResource
+	Content any `json:"content"`
 }
@@ -0,0 +1,312 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	v20250618 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250618"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// elicitationTimeout bounds how long a tool invocation waits for the user to
+// answer an elicitation request.
+const elicitationTimeout = 10 * time.Minute
+
+// elicitationSessionTimeout is how long an idle streamable HTTP session keeps
+// its elicitation capability.
+const elicitationSessionTimeout = time.Hour
+
+// elicitationResponse is the message sent by the client in response to an
+// elicitation request.
+type elicitationResponse struct {
+	Jsonrpc string              `json:"jsonrpc"`
+	Id      jsonrpc.RequestId   `json:"id"`
+	Method  string              `json:"method"`
+	Result  *tools.ElicitResult `json:"result"`
+	Error   *jsonrpc.Error      `json:"error"`
+}
+
+// pendingElicitation is an elicitation request waiting for the response of
+// the session it was sent to.
+type pendingElicitation struct {
+	sessionId string
+	ch        chan elicitationResponse
+}
+
+// elicitationManager tracks the streamable HTTP sessions of clients
+// supporting elicitation, and routes their responses to the pending
+// elicitation requests.
+type elicitationManager struct {
+	mu       sync.Mutex
+	sessions map[string]time.Time
+	pending  map[string]pendingElicitation
+}
+
+func newElicitationManager(ctx context.Context) *elicitationManager {
+	m := &elicitationManager{
+		sessions: make(map[string]time.Time),
+		pending:  make(map[string]pendingElicitation),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+func (m *elicitationManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			now := time.Now()
+			for id, lastActive := range m.sessions {
+				if now.Sub(lastActive) > elicitationSessionTimeout {
+					delete(m.sessions, id)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+// addSession creates a session for a client supporting elicitation.
+func (m *elicitationManager) addSession() string {
+	id := uuid.New().String()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[id] = time.Now()
+	return id
+}
+
+// hasSession returns true if the session supports elicitation.
+func (m *elicitationManager) hasSession(id string) bool {
+	if m == nil || id == "" {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return false
+	}
+	m.sessions[id] = time.Now()
+	return true
+}
+
+func (m *elicitationManager) removeSession(id string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+}
+
+// register returns the channel receiving the response to the request id,
+// which is only accepted from the session the request was sent to.
+func (m *elicitationManager) register(id, sessionId string) chan elicitationResponse {
+	ch := make(chan elicitationResponse, 1)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[id] = pendingElicitation{sessionId: sessionId, ch: ch}
+	return ch
+}
+
+func (m *elicitationManager) unregister(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, id)
+}
+
+// deliver routes the message of the session to the pending elicitation
+// request it answers. It returns false if the message isn't a response to a
+// request pending on that session.
+func (m *elicitationManager) deliver(body []byte, sessionId string) bool {
+	if m == nil || sessionId == "" {
+		return false
+	}
+	res, ok := parseElicitationResponse(body)
+	if !ok {
+		return false
+	}
+	id := fmt.Sprint(res.Id)
+	m.mu.Lock()
+	p, ok := m.pending[id]
+	ok = ok && p.sessionId == sessionId
+	if ok {
+		delete(m.pending, id)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+	p.ch <- res
+	return true
+}
+
+// parseElicitationResponse returns the message as a response, if it isn't a
+// request or a notification.
+func parseElicitationResponse(body []byte) (elicitationResponse, bool) {
+	var res elicitationResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return res, false
+	}
+	if res.Method != "" || res.Id == nil || (res.Result == nil && res.Error == nil) {
+		return res, false
+	}
+	return res, true
+}
+
+// clientSupportsElicitation returns true if the initialize request declares
+// the elicitation capability and the negotiated protocol version supports it.
+func clientSupportsElicitation(body []byte, protocolVersion string) bool {
+	if protocolVersion < v20250618.PROTOCOL_VERSION {
+		return false
+	}
+	var req mcputil.InitializeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false
+	}
+	return req.Params.Capabilities.Elicitation != nil
+}
+
+// newElicitationRequest builds the `elicitation/create` request sent to the
+// client.
+func newElicitationRequest(id, message string, requestedSchema map[string]any) jsonrpc.JSONRPCRequest {
+	return jsonrpc.JSONRPCRequest{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Request: jsonrpc.Request{Method: mcputil.ELICITATION_CREATE},
+		Params:  mcputil.ElicitRequestParams{Message: message, RequestedSchema: requestedSchema},
+	}
+}
+
+// toElicitResult converts the response of the client to an elicitation
+// result.
+func toElicitResult(res elicitationResponse) (tools.ElicitResult, error) {
+	if res.Error != nil {
+		return tools.ElicitResult{}, fmt.Errorf("elicitation failed: %s", res.Error.Message)
+	}
+	return *res.Result, nil
+}
+
+var _ tools.Elicitor = &httpElicitor{}
+
+// httpElicitor sends elicitation requests over the streamable HTTP
+// transport. The first request switches the response of the tool call to an
+// SSE stream, on which the result of the tool call is sent as well.
+type httpElicitor struct {
+	manager   *elicitationManager
+	sessionId string
+	w         http.ResponseWriter
+
+	mu        sync.Mutex
+	streaming bool
+}
+
+func (e *httpElicitor) Elicit(ctx context.Context, message string, requestedSchema map[string]any) (tools.ElicitResult, error) {
+	id := uuid.New().String()
+	ch := e.manager.register(id, e.sessionId)
+	defer e.manager.unregister(id)
+
+	if err := e.send(newElicitationRequest(id, message, requestedSchema)); err != nil {
+		return tools.ElicitResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, elicitationTimeout)
+	defer cancel()
+	select {
+	case res := <-ch:
+		return toElicitResult(res)
+	case <-ctx.Done():
+		return tools.ElicitResult{}, fmt.Errorf("no response to the elicitation request: %w", ctx.Err())
+	}
+}
+
+// send writes the message as an SSE event, starting the stream if needed.
+func (e *httpElicitor) send(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("unable to marshal message: %w", err)
+	}
+	flusher, ok := e.w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("streaming is not supported by the connection")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.streaming {
+		e.w.Header().Set("Content-Type", "text/event-stream")
+		e.w.Header().Set("Cache-Control", "no-cache")
+		e.w.WriteHeader(http.StatusOK)
+		e.streaming = true
+	}
+	if _, err := fmt.Fprintf(e.w, "event: message\ndata: %s\n\n", data); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
+
+// isStreaming returns true if the response was switched to an SSE stream.
+func (e *httpElicitor) isStreaming() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.streaming
+}
+
+// acceptsEventStream returns true if the client accepts SSE responses, as
+// required by the streamable HTTP transport.
+func acceptsEventStream(h http.Header) bool {
+	return strings.Contains(h.Get("Accept"), "text/event-stream")
+}
+
+var _ tools.Elicitor = &stdioSession{}
+
+// Elicit sends the elicitation request on stdout and reads stdin until the
+// response is received. Other messages read meanwhile are processed once the
+// current message is handled.
+func (s *stdioSession) Elicit(ctx context.Context, message string, requestedSchema map[string]any) (tools.ElicitResult, error) {
+	id := uuid.New().String()
+	if err := s.write(ctx, newElicitationRequest(id, message, requestedSchema)); err != nil {
+		return tools.ElicitResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, elicitationTimeout)
+	defer cancel()
+	for {
+		line, err := s.readLine(ctx)
+		if err != nil {
+			return tools.ElicitResult{}, fmt.Errorf("no response to the elicitation request: %w", err)
+		}
+		if res, ok := parseElicitationResponse([]byte(line)); ok && fmt.Sprint(res.Id) == id {
+			return toElicitResult(res)
+		}
+		s.queued = append(s.queued, line)
+	}
+}
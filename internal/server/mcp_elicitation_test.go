@@ -0,0 +1,225 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// mockToolConfig initializes the wrapped mock tool.
+type mockToolConfig struct {
+	tool MockTool
+}
+
+func (c mockToolConfig) ToolConfigType() string { return "mock" }
+
+func (c mockToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return c.tool, nil
+}
+
+// readEvent reads the data of the next SSE event.
+func readEvent(t *testing.T, r *bufio.Reader) map[string]any {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unable to read event: %s", err)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var msg map[string]any
+			if err := json.Unmarshal([]byte(data), &msg); err != nil {
+				t.Fatalf("unable to unmarshal event: %s", err)
+			}
+			return msg
+		}
+	}
+}
+
+func TestMcpElicitation(t *testing.T) {
+	confirmTool := MockTool{Name: "confirm_tool"}
+	confirmTool.manifest = confirmTool.Manifest()
+	wrapped, err := tools.ConfirmationConfig{ToolConfig: mockToolConfig{tool: confirmTool}}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	toolsMap := map[string]tools.Tool{"confirm_tool": wrapped}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"confirm_tool"}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	promptset, err := prompts.PromptsetConfig{Name: ""}.Initialize(fakeVersionString, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize promptset: %s", err)
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset}, nil, map[string]prompts.Promptset{"": promptset})
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	initialize := func(capabilities map[string]any) string {
+		body, _ := json.Marshal(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "mcp-initialize",
+			"method":  "initialize",
+			"params":  map[string]any{"protocolVersion": "2025-06-18", "capabilities": capabilities},
+		})
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(body), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		return resp.Header.Get("Mcp-Session-Id")
+	}
+	callBody, _ := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "confirm_tool", "arguments": map[string]any{}},
+	})
+	header := func(sessionId string) map[string]string {
+		return map[string]string{
+			"Mcp-Session-Id":       sessionId,
+			"MCP-Protocol-Version": "2025-06-18",
+			"Accept":               "application/json, text/event-stream",
+		}
+	}
+
+	t.Run("client without elicitation", func(t *testing.T) {
+		if sessionId := initialize(map[string]any{}); sessionId != "" {
+			t.Fatalf("unexpected session for a client without elicitation: %s", sessionId)
+		}
+		_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(callBody), header(""))
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if !strings.Contains(string(body), "client does not support elicitation") || !strings.Contains(string(body), `"isError":true`) {
+			t.Fatalf("expected confirmation tool error, got %s", body)
+		}
+	})
+
+	for _, action := range []string{tools.ElicitActionAccept, tools.ElicitActionDecline} {
+		t.Run("client with elicitation "+action, func(t *testing.T) {
+			sessionId := initialize(map[string]any{"elicitation": map[string]any{}})
+			if sessionId == "" {
+				t.Fatalf("expected a session for a client with elicitation")
+			}
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL+"/", bytes.NewBuffer(callBody))
+			if err != nil {
+				t.Fatalf("unable to create request: %s", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range header(sessionId) {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("unable to send request: %s", err)
+			}
+			defer resp.Body.Close()
+			if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+				t.Fatalf("expected an SSE response, got %s", ct)
+			}
+			stream := bufio.NewReader(resp.Body)
+
+			elicitation := readEvent(t, stream)
+			if elicitation["method"] != "elicitation/create" {
+				t.Fatalf("expected elicitation request, got %v", elicitation)
+			}
+			params, _ := elicitation["params"].(map[string]any)
+			if msg, _ := params["message"].(string); !strings.Contains(msg, "confirm_tool") {
+				t.Errorf("unexpected elicitation message: %v", params["message"])
+			}
+
+			answer, _ := json.Marshal(map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      elicitation["id"],
+				"result":  map[string]any{"action": action},
+			})
+			otherSessionId := initialize(map[string]any{"elicitation": map[string]any{}})
+			otherResp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(answer), header(otherSessionId))
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if otherResp.StatusCode == http.StatusAccepted {
+				t.Fatalf("expected the response of another session to be rejected")
+			}
+
+			answerResp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(answer), header(sessionId))
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if answerResp.StatusCode != http.StatusAccepted {
+				t.Fatalf("expected 202 for the elicitation response, got %d", answerResp.StatusCode)
+			}
+
+			result := readEvent(t, stream)
+			if result["id"] != "tools-call" {
+				t.Fatalf("expected tool call result, got %v", result)
+			}
+			got, _ := json.Marshal(result["result"])
+			if action == tools.ElicitActionAccept && strings.Contains(string(got), `"isError":true`) {
+				t.Errorf("expected the tool to run, got %s", got)
+			}
+			if action == tools.ElicitActionDecline && !strings.Contains(string(got), "did not confirm") {
+				t.Errorf("expected the tool to be declined, got %s", got)
+			}
+		})
+	}
+}
+
+func TestStdioElicitationTimeout(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	var out bytes.Buffer
+	session := NewStdioSession(&Server{}, pr, &out)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := session.Elicit(ctx, "Confirm?", map[string]any{}); err == nil {
+		t.Fatalf("expected the elicitation to time out")
+	}
+	if !strings.Contains(out.String(), "elicitation/create") {
+		t.Fatalf("expected elicitation request, got %s", out.String())
+	}
+
+	// the message following the timed out elicitation is read once, by the
+	// next read
+	msg := `{"jsonrpc": "2.0", "id": "1", "method": "tools/list"}` + "\n"
+	go func() {
+		_, _ = fmt.Fprint(pw, msg)
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	line, err := session.readLine(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if line != msg {
+		t.Fatalf("unexpected line: got %s, want %s", line, msg)
+	}
+}
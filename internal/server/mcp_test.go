@@ -27,6 +27,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/introspection"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	"github.com/googleapis/genai-toolbox/internal/server/resources"
@@ -1200,3 +1202,42 @@ func TestSseManagerGetNilSessionValue(t *testing.T) {
 		t.Error("expected nil session for nil session value")
 	}
 }
+
+func TestMCPAuthUnavailable(t *testing.T) {
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer endpoint.Close()
+
+	authService, err := introspection.Config{
+		Name:                  "my-introspection",
+		Type:                  introspection.AuthServiceType,
+		IntrospectionEndpoint: endpoint.URL,
+		McpEnabled:            true,
+		AuthorizationServer:   "https://auth.example.com",
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize auth service: %v", err)
+	}
+	s := &Server{
+		toolboxUrl:  "https://my-toolbox.example.com",
+		ResourceMgr: resources.NewResourceManager(nil, map[string]auth.AuthService{"my-introspection": authService}, nil, nil, nil, nil, nil),
+	}
+	handler := mcpAuthMiddleware(s)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected the request to be rejected")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer my-token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// the client isn't asked to authenticate again while the token can't be
+	// verified
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if got := rec.Header().Get("WWW-Authenticate"); got != "" {
+		t.Errorf("expected no WWW-Authenticate header, got %q", got)
+	}
+}
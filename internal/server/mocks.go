@@ -158,3 +158,14 @@ func (p MockPrompt) McpManifest() prompts.McpManifest {
 func (p MockPrompt) ToConfig() prompts.PromptConfig {
 	return nil
 }
+
+// MockAuthorizedPrompt is used to mock prompts restricted to some callers in
+// tests
+type MockAuthorizedPrompt struct {
+	MockPrompt
+	Policies tools.Policies
+}
+
+func (p MockAuthorizedPrompt) Authorize(claimsFromAuth map[string]map[string]any) util.ToolboxError {
+	return p.Policies.Check(claimsFromAuth)
+}
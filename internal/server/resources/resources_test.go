@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
@@ -59,7 +60,7 @@ func TestUpdateServer(t *testing.T) {
 	resMgr := resources.NewResourceManager(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets)
 
 	gotSource, _ := resMgr.GetSource("example-source")
-	if diff := cmp.Diff(gotSource, newSources["example-source"]); diff != "" {
+	if diff := cmp.Diff(gotSource, newSources["example-source"], cmpopts.IgnoreUnexported(alloydbpg.Source{})); diff != "" {
 		t.Errorf("error updating server, sources (-want +got):\n%s", diff)
 	}
 
@@ -99,7 +100,7 @@ func TestUpdateServer(t *testing.T) {
 
 	resMgr.SetResources(updateSource, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets)
 	gotSource, _ = resMgr.GetSource("example-source2")
-	if diff := cmp.Diff(gotSource, updateSource["example-source2"]); diff != "" {
+	if diff := cmp.Diff(gotSource, updateSource["example-source2"], cmpopts.IgnoreUnexported(alloydbpg.Source{})); diff != "" {
 		t.Errorf("error updating server, sources (-want +got):\n%s", diff)
 	}
 }
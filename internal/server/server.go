@@ -33,7 +33,6 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httplog/v3"
 	"github.com/googleapis/genai-toolbox/internal/auth"
-	"github.com/googleapis/genai-toolbox/internal/auth/generic"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/prompts"
@@ -56,8 +55,11 @@ type Server struct {
 	logger          log.Logger
 	instrumentation *telemetry.Instrumentation
 	sseManager      *sseManager
-	ResourceMgr     *resources.ResourceManager
-	mcpPrmFile      string
+	// elicitationManager tracks the elicitation requests sent over
+	// streamable HTTP
+	elicitationManager *elicitationManager
+	ResourceMgr        *resources.ResourceManager
+	mcpPrmFile         string
 }
 
 func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
@@ -191,6 +193,9 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 		}
 		toolsMap[name] = t
 	}
+	if err := tools.CheckToolReferences(toolsMap); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
 	toolNames := make([]string, 0, len(toolsMap))
 	for name := range toolsMap {
 		toolNames = append(toolNames, name)
@@ -206,6 +211,8 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 		cfg.ToolsetConfigs = make(ToolsetConfigs)
 	}
 	cfg.ToolsetConfigs[""] = tools.ToolsetConfig{Name: "", ToolNames: allToolNames}
+	// the policies of a toolset also apply to its tools reached elsewhere
+	tools.ApplyToolsetPolicies(toolsMap, cfg.ToolsetConfigs)
 
 	// initialize and validate the toolsets from configs
 	toolsetsMap := make(map[string]tools.Toolset)
@@ -377,15 +384,16 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	resourceManager := resources.NewResourceManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
 
 	s := &Server{
-		version:         cfg.Version,
-		srv:             srv,
-		root:            r,
-		logger:          l,
-		instrumentation: instrumentation,
-		sseManager:      sseManager,
-		ResourceMgr:     resourceManager,
-		toolboxUrl:      cfg.ToolboxUrl,
-		mcpPrmFile:      cfg.McpPrmFile,
+		version:            cfg.Version,
+		srv:                srv,
+		root:               r,
+		logger:             l,
+		instrumentation:    instrumentation,
+		sseManager:         sseManager,
+		elicitationManager: newElicitationManager(ctx),
+		ResourceMgr:        resourceManager,
+		toolboxUrl:         cfg.ToolboxUrl,
+		mcpPrmFile:         cfg.McpPrmFile,
 	}
 
 	// cors
@@ -418,7 +426,7 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	// Host OAuth Protected Resource Metadata endpoint
 	mcpAuthEnabled := false
 	for _, authSvc := range s.ResourceMgr.GetAuthServiceMap() {
-		if genCfg, ok := authSvc.ToConfig().(generic.Config); ok && genCfg.McpEnabled {
+		if mcpCfg, ok := authSvc.ToConfig().(auth.MCPAuthServiceConfig); ok && mcpCfg.IsMcpEnabled() {
 			mcpAuthEnabled = true
 			break
 		}
@@ -489,22 +497,17 @@ func mcpAuthMiddleware(s *Server) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Find McpEnabled auth service
-			var mcpSvc *generic.AuthService
-			for _, authSvc := range s.ResourceMgr.GetAuthServiceMap() {
-				if genSvc, ok := authSvc.(*generic.AuthService); ok && genSvc.McpEnabled {
-					mcpSvc = genSvc
-					break
-				}
-			}
+			mcpSvc, ok := auth.FindMCPAuthService(s.ResourceMgr.GetAuthServiceMap())
 
 			// MCP Auth not enabled
-			if mcpSvc == nil {
+			if !ok {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			if err := mcpSvc.ValidateMCPAuth(r.Context(), r.Header); err != nil {
-				var mcpErr *generic.MCPAuthError
+			claims, err := mcpSvc.ValidateMCPAuth(r.Context(), r.Header)
+			if err != nil {
+				var mcpErr *auth.MCPAuthError
 				if errors.As(err, &mcpErr) {
 					switch mcpErr.Code {
 					case http.StatusUnauthorized:
@@ -519,10 +522,19 @@ func mcpAuthMiddleware(s *Server) func(http.Handler) http.Handler {
 						w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s", resource_metadata="%s", error_description="%s"`, strings.Join(mcpErr.ScopesRequired, " "), s.toolboxUrl+"/.well-known/oauth-protected-resource", mcpErr.Message))
 						http.Error(w, mcpErr.Message, http.StatusForbidden)
 						return
+					default:
+						// the token couldn't be verified, e.g. the
+						// authorization server is unavailable
+						http.Error(w, mcpErr.Message, mcpErr.Code)
+						return
 					}
 				}
 			}
 
+			// make the claims available to tools and authenticated parameters
+			if claims != nil {
+				r = r.WithContext(auth.WithMCPClaims(r.Context(), mcpSvc.GetName(), claims))
+			}
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -23,6 +23,7 @@ import (
 	"cloud.google.com/go/alloydbconn"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -59,6 +60,9 @@ type Config struct {
 	User     string         `yaml:"user"`
 	Password string         `yaml:"password"`
 	Database string         `yaml:"database" validate:"required"`
+	// TokenExchange, if set, connects as the IAM user of the caller with an
+	// access token exchanged for their token.
+	TokenExchange *sources.TokenExchangeConfig `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -79,6 +83,15 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		tracer: tracer,
+	}
+	if r.TokenExchange != nil {
+		s.callerPools, err = r.TokenExchange.Initialize("email", func(_ string, value any) {
+			value.(*pgxpool.Pool).Close()
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
@@ -87,7 +100,11 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Pool *pgxpool.Pool
+	Pool   *pgxpool.Pool
+	tracer trace.Tracer
+	// callerPools holds the connection pools of callers connecting with
+	// exchanged access tokens
+	callerPools *sources.CallerPools
 }
 
 func (s *Source) SourceType() string {
@@ -102,8 +119,38 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// pool returns the connection pool of the caller. The returned func releases
+// the pool once the caller is done with it.
+func (s *Source) pool(ctx context.Context) (*pgxpool.Pool, func(), error) {
+	if s.callerPools == nil {
+		return s.PostgresPool(), func() {}, nil
+	}
+	callerPool, release, err := s.callerPools.Get(ctx, func(c sources.CallerCredentials) (any, error) {
+		user, err := sources.FormatIAMUser(c.User, "postgres")
+		if err != nil {
+			return nil, err
+		}
+		// the access token is the password of the IAM user
+		pool, err := initAlloyDBPgConnectionPool(ctx, s.tracer, s.Name, s.Project, s.Region, s.Cluster, s.Instance, s.IPType.String(), user, c.Token, s.Database)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create pool for user %q: %w", user, err)
+		}
+		return pool, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return callerPool.(*pgxpool.Pool), release, nil
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.Pool.Query(ctx, statement, params...)
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	results, err := pool.Query(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -129,6 +176,44 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	return out, nil
 }
 
+// DryRunSQL executes the statement in a transaction that is always rolled
+// back, and reports the rows it affects.
+func (s *Source) DryRunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return postgres.DryRunSQL(ctx, pool, "", statement, params)
+}
+
+// ReadOnlySQL executes the statement in a read-only transaction.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return postgres.ReadOnlySQL(ctx, pool, "", statement, params)
+}
+
+// RunSQLBatch executes the statement once for each set of params, in a
+// single transaction, and returns the rows of every execution.
+func (s *Source) RunSQLBatch(ctx context.Context, statement string, batch [][]any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return postgres.RunSQLBatch(ctx, pool, "", statement, batch)
+}
+
+// ConnectsAsCaller returns true if queries run with the credentials of the
+// caller instead of those of the source.
+func (s *Source) ConnectsAsCaller() bool {
+	return s.callerPools != nil
+}
+
 func getOpts(ipType, userAgent string, useIAM bool) ([]alloydbconn.Option, error) {
 	opts := []alloydbconn.Option{alloydbconn.WithUserAgent(userAgent)}
 	switch strings.ToLower(ipType) {
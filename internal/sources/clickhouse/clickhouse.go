@@ -21,7 +21,7 @@ import (
 	"net/url"
 	"time"
 
-	_ "github.com/ClickHouse/clickhouse-go/v2"
+	ch "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -100,6 +100,17 @@ func (s *Source) ClickHousePool() *sql.DB {
 	return s.Pool
 }
 
+// ReadOnlySQL executes a single read-only statement with the `readonly`
+// setting enabled, so that ClickHouse rejects any statement that writes data
+// or changes settings.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
+	if !sources.IsReadOnlyStatement(SourceType, statement) {
+		return nil, fmt.Errorf("only a single read-only statement, such as SELECT, can be executed")
+	}
+	ctx = ch.Context(ctx, ch.WithSettings(ch.Settings{"readonly": 1}))
+	return s.RunSQL(ctx, statement, params)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
 	var sliceParams []any
 	if params != nil {
@@ -18,14 +18,17 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/url"
 	"slices"
 
+	"cloud.google.com/go/cloudsqlconn"
 	"cloud.google.com/go/cloudsqlconn/sqlserver/mssql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	mssqldb "github.com/microsoft/go-mssqldb"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -59,6 +62,9 @@ type Config struct {
 	User     string         `yaml:"user" validate:"required"`
 	Password string         `yaml:"password" validate:"required"`
 	Database string         `yaml:"database" validate:"required"`
+	// TokenExchange, if set, connects as the caller with a Microsoft Entra
+	// access token exchanged for their token.
+	TokenExchange *sources.TokenExchangeConfig `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -82,6 +88,28 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Db:     db,
+		tracer: tracer,
+	}
+	if r.TokenExchange != nil {
+		userAgent, err := util.UserAgentFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts, err := sources.GetCloudSQLOpts(r.IPType.String(), userAgent, false)
+		if err != nil {
+			return nil, err
+		}
+		s.dialer, err = cloudsqlconn.NewDialer(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create dialer: %w", err)
+		}
+		// the access token identifies the caller, so no user claim is read
+		s.callerPools, err = r.TokenExchange.Initialize("", func(_ string, value any) {
+			_ = value.(*sql.DB).Close()
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
@@ -90,7 +118,13 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Db *sql.DB
+	Db     *sql.DB
+	tracer trace.Tracer
+	// dialer connects the connection pools of callers to the instance
+	dialer *cloudsqlconn.Dialer
+	// callerPools holds the connection pools of callers connecting with
+	// exchanged access tokens
+	callerPools *sources.CallerPools
 }
 
 func (s *Source) SourceType() string {
@@ -108,7 +142,23 @@ func (s *Source) MSSQLDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MSSQLDB().QueryContext(ctx, statement, params...)
+	db := s.MSSQLDB()
+	if s.callerPools != nil {
+		callerDB, release, err := s.callerPools.Get(ctx, func(c sources.CallerCredentials) (any, error) {
+			db, err := initCloudSQLMssqlTokenConnection(ctx, s.tracer, s.Name, s.Project, s.Region, s.Instance, s.dialer, c.Token, s.Database)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create db connection for caller: %w", err)
+			}
+			return db, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		db = callerDB.(*sql.DB)
+	}
+
+	results, err := db.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -195,3 +245,45 @@ func initCloudSQLMssqlConnection(ctx context.Context, tracer trace.Tracer, name,
 	}
 	return db, nil
 }
+
+// cloudSQLDialer dials the instance through the Cloud SQL connector.
+type cloudSQLDialer struct {
+	d        *cloudsqlconn.Dialer
+	instance string
+}
+
+func (c cloudSQLDialer) DialContext(ctx context.Context, _, _ string) (net.Conn, error) {
+	return c.d.Dial(ctx, c.instance)
+}
+
+// initCloudSQLMssqlTokenConnection opens a connection pool authenticated with
+// a Microsoft Entra access token instead of a user and password.
+func initCloudSQLMssqlTokenConnection(ctx context.Context, tracer trace.Tracer, name, project, region, instance string, d *cloudsqlconn.Dialer, token, dbname string) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create dsn
+	query := url.Values{}
+	query.Add("app name", userAgent)
+	query.Add("database", dbname)
+
+	url := &url.URL{
+		Scheme:   "sqlserver",
+		RawQuery: query.Encode(),
+	}
+
+	connector, err := mssqldb.NewConnectorWithAccessTokenProvider(url.String(), func(context.Context) (string, error) {
+		return token, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connector: %w", err)
+	}
+	connector.Dialer = cloudSQLDialer{d: d, instance: fmt.Sprintf("%s:%s:%s", project, region, instance)}
+	return sql.OpenDB(connector), nil
+}
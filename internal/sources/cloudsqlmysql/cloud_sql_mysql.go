@@ -24,6 +24,7 @@ import (
 	"cloud.google.com/go/cloudsqlconn/mysql/mysql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	mysqlsrc "github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
@@ -59,6 +60,9 @@ type Config struct {
 	User     string         `yaml:"user"`
 	Password string         `yaml:"password"`
 	Database string         `yaml:"database"`
+	// TokenExchange, if set, connects as the IAM user of the caller with an
+	// access token exchanged for their token.
+	TokenExchange *sources.TokenExchangeConfig `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -66,7 +70,7 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initCloudSQLMySQLConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	pool, err := initCloudSQLMySQLConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database, false)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -79,6 +83,15 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		tracer: tracer,
+	}
+	if r.TokenExchange != nil {
+		s.callerPools, err = r.TokenExchange.Initialize("email", func(_ string, value any) {
+			_ = value.(*sql.DB).Close()
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
@@ -87,7 +100,11 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Pool *sql.DB
+	Pool   *sql.DB
+	tracer trace.Tracer
+	// callerPools holds the connection pools of callers connecting with
+	// exchanged access tokens
+	callerPools *sources.CallerPools
 }
 
 func (s *Source) SourceType() string {
@@ -102,8 +119,58 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+// pool returns the connection pool of the caller. The returned func releases
+// the pool once the caller is done with it.
+func (s *Source) pool(ctx context.Context) (*sql.DB, func(), error) {
+	if s.callerPools == nil {
+		return s.MySQLPool(), func() {}, nil
+	}
+	callerPool, release, err := s.callerPools.Get(ctx, func(c sources.CallerCredentials) (any, error) {
+		user, err := sources.FormatIAMUser(c.User, "mysql")
+		if err != nil {
+			return nil, err
+		}
+		pool, err := initCloudSQLMySQLConnectionPool(ctx, s.tracer, s.Name, s.Project, s.Region, s.Instance, s.IPType.String(), user, c.Token, s.Database, true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create pool for user %q: %w", user, err)
+		}
+		return pool, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return callerPool.(*sql.DB), release, nil
+}
+
+// DryRunSQL executes the statement in a transaction that is always rolled
+// back, and reports the rows it affects.
+func (s *Source) DryRunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return mysqlsrc.DryRunSQL(ctx, pool, statement, params)
+}
+
+// ReadOnlySQL executes a read-only statement in a read-only transaction.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return mysqlsrc.ReadOnlySQL(ctx, pool, statement, params)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	results, err := pool.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -182,7 +249,10 @@ func getConnectionConfig(ctx context.Context, user, pass string) (string, string
 	return user, pass, useIAM, nil
 }
 
-func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*sql.DB, error) {
+// initCloudSQLMySQLConnectionPool opens a connection pool to the instance.
+// If accessToken is set, pass is an access token of the IAM user, which is
+// sent as a cleartext password over the encrypted connection of the connector.
+func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string, accessToken bool) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
@@ -205,6 +275,11 @@ func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, n
 
 	// Use a unique driver name based on the source name.
 	driverName := fmt.Sprintf("cloudsql-mysql-%s", name)
+	if accessToken {
+		// the driver of the source may authenticate with the IAM principal
+		// of the server instead
+		driverName += "-caller"
+	}
 
 	if !slices.Contains(sql.Drivers(), driverName) {
 		if _, err := mysql.RegisterDriver(driverName, opts...); err != nil {
@@ -237,6 +312,10 @@ func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, n
 		)
 	}
 
+	if accessToken {
+		dsn += "&allowCleartextPasswords=true"
+	}
+
 	db, err := sql.Open(
 		driverName,
 		dsn,
@@ -97,7 +97,30 @@ func (s *Source) FirebirdDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	rows, err := s.FirebirdDB().QueryContext(ctx, statement, params...)
+	return runSQL(ctx, s.FirebirdDB(), statement, params)
+}
+
+// ReadOnlySQL executes a single read-only statement in a read-only
+// transaction, so that Firebird rejects any statement that writes data.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	if !sources.IsReadOnlyStatement(SourceType, statement) {
+		return nil, fmt.Errorf("only a single read-only statement, such as SELECT, can be executed")
+	}
+	tx, err := s.FirebirdDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	return runSQL(ctx, tx, statement, params)
+}
+
+// querier is implemented by pools and transactions
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, statement string, params []any) (any, error) {
+	rows, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
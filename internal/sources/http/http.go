@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth/tokenexchange"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
@@ -58,6 +59,9 @@ type Config struct {
 	QueryParams            map[string]string `yaml:"queryParams"`
 	ReturnFullError        bool              `yaml:"returnFullError"`
 	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	// TokenExchange, if set, exchanges the caller's token for a credential
+	// sent as the Authorization header of requests.
+	TokenExchange *tokenexchange.Config `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -113,6 +117,12 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Config: r,
 		client: &client,
 	}
+	if r.TokenExchange != nil {
+		s.exchanger, err = r.TokenExchange.Initialize()
+		if err != nil {
+			return nil, fmt.Errorf("invalid tokenExchange: %w", err)
+		}
+	}
 	return s, nil
 
 }
@@ -121,7 +131,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	client *http.Client
+	client    *http.Client
+	exchanger *tokenexchange.Exchanger
 }
 
 func (s *Source) SourceType() string {
@@ -149,6 +160,15 @@ func (s *Source) Client() *http.Client {
 }
 
 func (s *Source) RunRequest(ctx context.Context, req *http.Request) (any, error) {
+	// Act on behalf of the caller
+	if s.exchanger != nil {
+		token, err := s.exchanger.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
 	// Make request and fetch response
 	resp, err := s.Client().Do(req)
 	if err != nil {
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/tokenexchange"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -85,6 +87,39 @@ func TestParseFromYamlHttp(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "token exchange",
+			in: `
+			kind: source
+			name: my-http-instance
+			type: http
+			baseUrl: http://test_server/
+			tokenExchange:
+				tokenEndpoint: https://sts.example.com/token
+				authService: my-auth
+				clientId: my-client
+				clientSecret: my-secret
+				audience: https://api.example.com
+				scopes:
+					- api.read
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": http.Config{
+					Name:    "my-http-instance",
+					Type:    http.SourceType,
+					BaseURL: "http://test_server/",
+					Timeout: "30s",
+					TokenExchange: &tokenexchange.Config{
+						TokenEndpoint: "https://sts.example.com/token",
+						AuthService:   "my-auth",
+						ClientId:      "my-client",
+						ClientSecret:  "my-secret",
+						Audience:      "https://api.example.com",
+						Scopes:        []string{"api.read"},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -226,3 +261,55 @@ func TestRunRequestIncludesErrorBodyWhenEnabled(t *testing.T) {
 		t.Fatalf("expected response body in error message, got %q", err.Error())
 	}
 }
+
+func TestRunRequestWithTokenExchange(t *testing.T) {
+	sts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("subject_token") != "user-token" {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_request"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"downstream-token","issued_token_type":"urn:ietf:params:oauth:token-type:access_token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer sts.Close()
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	logger, err := log.NewLogger("standard", log.Debug, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	sourceConfig := http.Config{
+		Name:          "test-http",
+		Type:          http.SourceType,
+		BaseURL:       server.URL,
+		Timeout:       "30s",
+		TokenExchange: &tokenexchange.Config{TokenEndpoint: sts.URL, AuthService: "my-auth"},
+	}
+	initialized, err := sourceConfig.Initialize(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to initialize source: %v", err)
+	}
+	source := initialized.(*http.Source)
+
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	if _, err := source.RunRequest(ctx, req); err == nil {
+		t.Fatalf("expected error without a caller token")
+	}
+
+	ctx = auth.WithSubjectTokens(ctx, map[string]auth.SubjectToken{"my-auth": {Token: "user-token", Claims: map[string]any{"sub": "user"}}})
+	got, err := source.RunRequest(ctx, req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Bearer downstream-token" {
+		t.Fatalf("expected the exchanged token to be sent, got %q", got)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	// ImpersonationModeRole switches the role of a shared connection for
+	// each request, e.g. with `SET ROLE` or `EXECUTE AS USER`.
+	ImpersonationModeRole = "role"
+	// ImpersonationModePool connects with the credentials of the mapped
+	// database user, using a connection pool per user.
+	ImpersonationModePool = "pool"
+
+	defaultMaxPools = 16
+)
+
+// ImpersonationConfig selects the database user of each request from a
+// verified claim of the caller.
+type ImpersonationConfig struct {
+	// AuthService is the name of the auth service whose claims are used.
+	AuthService string `yaml:"authService" validate:"required"`
+	// Claim is the name of the claim mapped to a database user.
+	Claim string `yaml:"claim" validate:"required"`
+	Mode  string `yaml:"mode" validate:"omitempty,oneof=role pool"`
+	// Users maps claim values to database users.
+	Users map[string]DatabaseUser `yaml:"users" validate:"required,dive"`
+	// MaxPools is the number of per-user connection pools kept open in
+	// "pool" mode. The least recently used pool is closed when it is full.
+	MaxPools int `yaml:"maxPools" validate:"gte=0"`
+}
+
+// DatabaseUser is the database user a claim value is mapped to.
+type DatabaseUser struct {
+	User string `yaml:"user" validate:"required"`
+	// Password is only used in "pool" mode.
+	Password string `yaml:"password"`
+}
+
+// Validate checks the config against the modes supported by the source,
+// defaulting to the first one.
+func (c *ImpersonationConfig) Validate(sourceType string, modes ...string) error {
+	if c.Mode == "" {
+		c.Mode = modes[0]
+	}
+	if !slices.Contains(modes, c.Mode) {
+		return fmt.Errorf("impersonation mode %q is not supported by %q sources, must be one of %q", c.Mode, sourceType, modes)
+	}
+	if len(c.Users) == 0 {
+		return fmt.Errorf("impersonation requires at least one user mapping")
+	}
+	if c.MaxPools == 0 {
+		c.MaxPools = defaultMaxPools
+	}
+	return nil
+}
+
+// DatabaseUser returns the database user mapped from the claim of the caller
+// in ctx.
+func (c *ImpersonationConfig) DatabaseUser(ctx context.Context) (DatabaseUser, error) {
+	subject, ok := auth.SubjectTokenFromContext(ctx, c.AuthService)
+	if !ok {
+		return DatabaseUser{}, util.NewClientServerError(
+			fmt.Sprintf("a verified token from auth service %q is required to connect as the caller", c.AuthService),
+			http.StatusUnauthorized,
+			nil,
+		)
+	}
+	value, ok := subject.Claims[c.Claim]
+	if !ok {
+		return DatabaseUser{}, util.NewClientServerError(
+			fmt.Sprintf("claim %q is missing from the token of auth service %q", c.Claim, c.AuthService),
+			http.StatusForbidden,
+			nil,
+		)
+	}
+	u, ok := c.Users[fmt.Sprint(value)]
+	if !ok {
+		return DatabaseUser{}, util.NewClientServerError(
+			fmt.Sprintf("no database user is mapped to claim %q value %q", c.Claim, fmt.Sprint(value)),
+			http.StatusForbidden,
+			nil,
+		)
+	}
+	return u, nil
+}
+
+// roleImpersonator is implemented by sources that can impersonate callers by
+// switching the role of a shared connection.
+type roleImpersonator interface {
+	ImpersonationMode() string
+}
+
+// CheckArbitraryStatements rejects sources impersonating callers in "role"
+// mode for tools running statements written by the caller. Switching roles
+// is not a security boundary against such statements, as they can switch
+// back to the role of the source, e.g. with `RESET ROLE` or `REVERT`.
+func CheckArbitraryStatements(src Source) error {
+	s, ok := src.(roleImpersonator)
+	if !ok || s.ImpersonationMode() != ImpersonationModeRole {
+		return nil
+	}
+	return fmt.Errorf("impersonation mode %q can't be used by tools running arbitrary statements, use mode %q instead", ImpersonationModeRole, ImpersonationModePool)
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestImpersonationDatabaseUser(t *testing.T) {
+	cfg := &sources.ImpersonationConfig{
+		AuthService: "my-auth",
+		Claim:       "email",
+		Users:       map[string]sources.DatabaseUser{"alice@example.com": {User: "alice"}},
+	}
+	if err := cfg.Validate("postgres", sources.ImpersonationModeRole, sources.ImpersonationModePool); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cfg.Mode != sources.ImpersonationModeRole {
+		t.Errorf("expected default mode %q, got %q", sources.ImpersonationModeRole, cfg.Mode)
+	}
+
+	withClaims := func(claims map[string]any) context.Context {
+		return auth.WithSubjectTokens(context.Background(), map[string]auth.SubjectToken{"my-auth": {Token: "token", Claims: claims}})
+	}
+	tcs := []struct {
+		desc string
+		ctx  context.Context
+		want string
+		code int
+	}{
+		{desc: "mapped user", ctx: withClaims(map[string]any{"email": "alice@example.com"}), want: "alice"},
+		{desc: "no token", ctx: context.Background(), code: http.StatusUnauthorized},
+		{desc: "missing claim", ctx: withClaims(map[string]any{"sub": "alice"}), code: http.StatusForbidden},
+		{desc: "unmapped claim", ctx: withClaims(map[string]any{"email": "bob@example.com"}), code: http.StatusForbidden},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := cfg.DatabaseUser(tc.ctx)
+			if tc.code == 0 {
+				if err != nil || got.User != tc.want {
+					t.Fatalf("got %q, %v, want %q", got.User, err, tc.want)
+				}
+				return
+			}
+			var csErr *util.ClientServerError
+			if !errors.As(err, &csErr) || csErr.Code != tc.code {
+				t.Fatalf("expected error with code %d, got %v", tc.code, err)
+			}
+		})
+	}
+}
+
+func TestImpersonationUnsupportedMode(t *testing.T) {
+	cfg := &sources.ImpersonationConfig{
+		AuthService: "my-auth",
+		Claim:       "email",
+		Mode:        sources.ImpersonationModeRole,
+		Users:       map[string]sources.DatabaseUser{"alice@example.com": {User: "alice"}},
+	}
+	err := cfg.Validate("mysql", sources.ImpersonationModePool)
+	if err == nil || !strings.Contains(err.Error(), `impersonation mode "role" is not supported`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type fakeSource struct{ mode string }
+
+func (fakeSource) SourceType() string             { return "fake" }
+func (fakeSource) ToConfig() sources.SourceConfig { return nil }
+func (s fakeSource) ImpersonationMode() string    { return s.mode }
+
+func TestCheckArbitraryStatements(t *testing.T) {
+	for _, mode := range []string{"", sources.ImpersonationModePool} {
+		if err := sources.CheckArbitraryStatements(fakeSource{mode: mode}); err != nil {
+			t.Errorf("mode %q: unexpected error: %s", mode, err)
+		}
+	}
+	if err := sources.CheckArbitraryStatements(nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	err := sources.CheckArbitraryStatements(fakeSource{mode: sources.ImpersonationModeRole})
+	if err == nil || !strings.Contains(err.Error(), `impersonation mode "role" can't be used`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	var evicted []string
+	c := sources.NewLRUCache(2, func(key string, _ any) { evicted = append(evicted, key) })
+	get := func(key string) func() {
+		t.Helper()
+		_, release, err := c.GetOrCreate(key, func() (any, error) { return key, nil })
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return release
+	}
+	get("a")()
+	get("b")()
+	get("a")() // "b" is now the least recently used
+	get("c")()
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("expected b to be evicted, got %v", evicted)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 items, got %d", c.Len())
+	}
+	if _, _, err := c.GetOrCreate("d", func() (any, error) { return nil, errors.New("boom") }); err == nil {
+		t.Errorf("expected creation error")
+	}
+	if c.Len() != 2 {
+		t.Errorf("failed creations should not be cached, got %d items", c.Len())
+	}
+
+	// items in use are only evicted once they are released
+	release := get("a")
+	releaseAgain := get("a")
+	get("d")()
+	get("e")()
+	if len(evicted) != 2 || evicted[1] != "c" {
+		t.Fatalf("expected c to be evicted, got %v", evicted)
+	}
+	release()
+	release() // releasing twice is a no-op
+	if len(evicted) != 2 {
+		t.Fatalf("a should not be evicted while in use, got %v", evicted)
+	}
+	releaseAgain()
+	if len(evicted) != 3 || evicted[2] != "a" {
+		t.Errorf("expected a to be evicted after its release, got %v", evicted)
+	}
+}
+
+func TestLRUCacheConcurrentCreate(t *testing.T) {
+	c := sources.NewLRUCache(2, nil)
+	var created atomic.Int32
+	unblock := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, release, err := c.GetOrCreate("a", func() (any, error) {
+				created.Add(1)
+				<-unblock
+				return "a", nil
+			})
+			if err != nil || v != "a" {
+				t.Errorf("got %v, %v", v, err)
+				return
+			}
+			release()
+		}()
+	}
+
+	// other keys are not blocked by a creation in progress
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, release, err := c.GetOrCreate("b", func() (any, error) { return "b", nil }); err == nil {
+			release()
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("creating b was blocked by the creation of a")
+	}
+	close(unblock)
+	wg.Wait()
+	if got := created.Load(); got != 1 {
+		t.Errorf("expected a single creation, got %d", got)
+	}
+}
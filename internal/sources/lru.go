@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRUCache is a thread-safe key-value store holding a bounded number of
+// items, evicting the least recently used item when it is full. Items are
+// reference counted: an evicted item is only passed to onEvict once every
+// caller holding it has released it.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	ll      *list.List
+	items   map[string]*list.Element
+	pending map[string]*lruCall
+	onEvict OnEvictFunc
+}
+
+type lruEntry struct {
+	key     string
+	value   any
+	refs    int
+	evicted bool
+}
+
+// lruCall is an in-flight creation of an item, shared by every caller
+// requesting the same key
+type lruCall struct {
+	done chan struct{}
+	err  error
+}
+
+// NewLRUCache creates a new cache holding at most size items
+func NewLRUCache(size int, onEvict OnEvictFunc) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		ll:      list.New(),
+		items:   make(map[string]*list.Element),
+		pending: make(map[string]*lruCall),
+		onEvict: onEvict,
+	}
+}
+
+// GetOrCreate retrieves an item from the cache, creating it if it is missing.
+// Concurrent callers requesting a missing key share a single creation, which
+// runs outside of the cache lock. The returned release func must be called
+// once the caller is done with the item: onEvict is called for an evicted
+// item after its last release.
+func (c *LRUCache) GetOrCreate(key string, create func() (any, error)) (any, func(), error) {
+	c.mu.Lock()
+	for {
+		if el, ok := c.items[key]; ok {
+			c.ll.MoveToFront(el)
+			entry := el.Value.(*lruEntry)
+			entry.refs++
+			c.mu.Unlock()
+			return entry.value, c.releaseFunc(entry), nil
+		}
+		call, ok := c.pending[key]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+		<-call.done
+		if call.err != nil {
+			return nil, nil, call.err
+		}
+		c.mu.Lock()
+	}
+
+	call := &lruCall{done: make(chan struct{})}
+	c.pending[key] = call
+	c.mu.Unlock()
+
+	value, err := create()
+
+	c.mu.Lock()
+	delete(c.pending, key)
+	call.err = err
+	close(call.done)
+	if err != nil {
+		c.mu.Unlock()
+		return nil, nil, err
+	}
+	entry := &lruEntry{key: key, value: value, refs: 1}
+	c.items[key] = c.ll.PushFront(entry)
+	var evicted *lruEntry
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+		if e := oldest.Value.(*lruEntry); e.refs == 0 {
+			evicted = e
+		} else {
+			e.evicted = true
+		}
+	}
+	c.mu.Unlock()
+
+	// evict outside of the lock, as closing a pool waits for its connections
+	c.evict(evicted)
+	return value, c.releaseFunc(entry), nil
+}
+
+// releaseFunc returns a func releasing the caller's reference to the entry,
+// evicting it if it was removed from the cache while in use
+func (c *LRUCache) releaseFunc(entry *lruEntry) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			entry.refs--
+			var evicted *lruEntry
+			if entry.evicted && entry.refs == 0 {
+				evicted = entry
+			}
+			c.mu.Unlock()
+			c.evict(evicted)
+		})
+	}
+}
+
+func (c *LRUCache) evict(entry *lruEntry) {
+	if entry != nil && c.onEvict != nil {
+		c.onEvict(entry.key, entry.value)
+	}
+}
+
+// Len returns the number of items in the cache
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
@@ -240,6 +240,31 @@ func (s *Source) UpdateMany(ctx context.Context, filterString string, canonical
 	return []any{res.ModifiedCount, res.UpsertedCount, res.MatchedCount}, nil
 }
 
+// DryRunUpdateMany reports the number of documents UpdateMany would match,
+// without modifying the collection.
+func (s *Source) DryRunUpdateMany(ctx context.Context, filterString string, canonical bool, updateString, database, collection string, upsert bool) (any, error) {
+	var filter = bson.D{}
+	err := bson.UnmarshalExtJSON([]byte(filterString), canonical, &filter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal filter string: %w", err)
+	}
+	var update = bson.D{}
+	err = bson.UnmarshalExtJSON([]byte(updateString), false, &update)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal update string: %w", err)
+	}
+
+	matched, err := s.MongoClient().Database(database).Collection(collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error counting documents: %w", err)
+	}
+	return map[string]any{
+		"dry_run":       true,
+		"matched_count": matched,
+		"would_upsert":  upsert && matched == 0,
+	}, nil
+}
+
 func (s *Source) UpdateOne(ctx context.Context, filterString string, canonical bool, updateString, database, collection string, upsert bool) (any, error) {
 	var filter = bson.D{}
 	err := bson.UnmarshalExtJSON([]byte(filterString), false, &filter)
@@ -17,14 +17,16 @@ package mssql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
-	_ "github.com/microsoft/go-mssqldb"
+	mssql "github.com/microsoft/go-mssqldb"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -57,6 +59,12 @@ type Config struct {
 	Password string `yaml:"password" validate:"required"`
 	Database string `yaml:"database" validate:"required"`
 	Encrypt  string `yaml:"encrypt"`
+	// Impersonation, if set, runs queries as the database user mapped from a
+	// claim of the caller.
+	Impersonation *sources.ImpersonationConfig `yaml:"impersonation"`
+	// TokenExchange, if set, connects as the caller with a Microsoft Entra
+	// access token exchanged for their token.
+	TokenExchange *sources.TokenExchangeConfig `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -65,6 +73,15 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if r.Impersonation != nil && r.TokenExchange != nil {
+		return nil, fmt.Errorf("impersonation and tokenExchange can't be used together")
+	}
+	if r.Impersonation != nil {
+		if err := r.Impersonation.Validate(SourceType, sources.ImpersonationModeRole, sources.ImpersonationModePool); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initializes a MSSQL source
 	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.Encrypt)
 	if err != nil {
@@ -80,6 +97,20 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Db:     db,
+		tracer: tracer,
+	}
+	closeDB := func(_ string, value any) {
+		_ = value.(*sql.DB).Close()
+	}
+	if r.Impersonation != nil && r.Impersonation.Mode == sources.ImpersonationModePool {
+		s.userPools = sources.NewLRUCache(r.Impersonation.MaxPools, closeDB)
+	}
+	if r.TokenExchange != nil {
+		// the access token identifies the caller, so no user claim is read
+		s.callerPools, err = r.TokenExchange.Initialize("", closeDB)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
@@ -88,7 +119,13 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Db *sql.DB
+	Db     *sql.DB
+	tracer trace.Tracer
+	// userPools holds the connection pools of impersonated users
+	userPools *sources.LRUCache
+	// callerPools holds the connection pools of callers connecting with
+	// exchanged access tokens
+	callerPools *sources.CallerPools
 }
 
 func (s *Source) SourceType() string {
@@ -105,8 +142,75 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
+// ImpersonationMode returns the mode used to impersonate callers, if any.
+func (s *Source) ImpersonationMode() string {
+	if s.Impersonation == nil {
+		return ""
+	}
+	return s.Impersonation.Mode
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MSSQLDB().QueryContext(ctx, statement, params...)
+	if s.callerPools != nil {
+		db, release, err := s.callerPools.Get(ctx, func(c sources.CallerCredentials) (any, error) {
+			db, err := initMssqlTokenConnection(ctx, s.tracer, s.Name, s.Host, s.Port, c.Token, s.Database, s.Encrypt)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create db connection for caller: %w", err)
+			}
+			return db, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return runSQL(ctx, db.(*sql.DB), statement, params)
+	}
+	if s.Impersonation == nil {
+		return runSQL(ctx, s.MSSQLDB(), statement, params)
+	}
+	u, err := s.Impersonation.DatabaseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Impersonation.Mode == sources.ImpersonationModePool {
+		db, release, err := s.userPools.GetOrCreate(u.User, func() (any, error) {
+			return initMssqlConnection(ctx, s.tracer, s.Name, s.Host, s.Port, u.User, u.Password, s.Database, s.Encrypt)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create db connection for user %q: %w", u.User, err)
+		}
+		defer release()
+		return runSQL(ctx, db.(*sql.DB), statement, params)
+	}
+
+	conn, err := s.MSSQLDB().Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+	defer conn.Close()
+	// EXECUTE AS is sent without parameters, as a context switch inside
+	// sp_executesql is reverted at the end of that call
+	quoted := "N'" + strings.ReplaceAll(u.User, "'", "''") + "'"
+	if _, err := conn.ExecContext(ctx, "EXECUTE AS USER = "+quoted); err != nil {
+		return nil, fmt.Errorf("unable to execute as user %q: %w", u.User, err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "REVERT"); err != nil {
+			// never return an impersonated connection to the pool
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+	return runSQL(ctx, conn, statement, params)
+}
+
+// querier is implemented by connection pools and connections
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, statement string, params []any) (any, error) {
+	results, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -159,6 +263,38 @@ func initMssqlConnection(
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
 
+	// Open database connection
+	db, err := sql.Open("sqlserver", mssqlDSN(ctx, host, port, url.UserPassword(user, pass), dbname, encrypt))
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
+
+// initMssqlTokenConnection opens a connection pool authenticated with a
+// Microsoft Entra access token instead of a user and password.
+func initMssqlTokenConnection(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name, host, port, token, dbname, encrypt string,
+) (
+	*sql.DB,
+	error,
+) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
+	defer span.End()
+
+	connector, err := mssql.NewAccessTokenConnector(mssqlDSN(ctx, host, port, nil, dbname, encrypt), func() (string, error) {
+		return token, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connector: %w", err)
+	}
+	return sql.OpenDB(connector), nil
+}
+
+func mssqlDSN(ctx context.Context, host, port string, user *url.Userinfo, dbname, encrypt string) string {
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
 		userAgent = "genai-toolbox"
@@ -171,17 +307,11 @@ func initMssqlConnection(
 		query.Add("encrypt", encrypt)
 	}
 
-	url := &url.URL{
+	dsn := &url.URL{
 		Scheme:   "sqlserver",
-		User:     url.UserPassword(user, pass),
+		User:     user,
 		Host:     fmt.Sprintf("%s:%s", host, port),
 		RawQuery: query.Encode(),
 	}
-
-	// Open database connection
-	db, err := sql.Open("sqlserver", url.String())
-	if err != nil {
-		return nil, fmt.Errorf("sql.Open: %w", err)
-	}
-	return db, nil
+	return dsn.String()
 }
@@ -58,6 +58,12 @@ type Config struct {
 	Database     string            `yaml:"database"`
 	QueryTimeout string            `yaml:"queryTimeout"`
 	QueryParams  map[string]string `yaml:"queryParams"`
+	// Impersonation, if set, runs queries as the database user mapped from a
+	// claim of the caller.
+	Impersonation *sources.ImpersonationConfig `yaml:"impersonation"`
+	// TokenExchange, if set, connects as the caller with a credential
+	// exchanged for their token, used as the password of their user.
+	TokenExchange *sources.TokenExchangeConfig `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -65,6 +71,17 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if r.Impersonation != nil && r.TokenExchange != nil {
+		return nil, fmt.Errorf("impersonation and tokenExchange can't be used together")
+	}
+	// MySQL can only switch to roles granted to the connected user, so each
+	// impersonated user gets its own pool
+	if r.Impersonation != nil {
+		if err := r.Impersonation.Validate(SourceType, sources.ImpersonationModePool); err != nil {
+			return nil, err
+		}
+	}
+
 	pool, err := initMySQLConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryTimeout, r.QueryParams)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
@@ -78,6 +95,19 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		tracer: tracer,
+	}
+	closePool := func(_ string, value any) {
+		_ = value.(*sql.DB).Close()
+	}
+	if r.Impersonation != nil {
+		s.userPools = sources.NewLRUCache(r.Impersonation.MaxPools, closePool)
+	}
+	if r.TokenExchange != nil {
+		s.callerPools, err = r.TokenExchange.Initialize("email", closePool)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
@@ -86,7 +116,13 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Pool *sql.DB
+	Pool   *sql.DB
+	tracer trace.Tracer
+	// userPools holds the connection pools of impersonated users
+	userPools *sources.LRUCache
+	// callerPools holds the connection pools of callers connecting with
+	// exchanged credentials
+	callerPools *sources.CallerPools
 }
 
 func (s *Source) SourceType() string {
@@ -101,8 +137,127 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+// pool returns the connection pool of the caller. The returned func releases
+// the pool once the caller is done with it.
+func (s *Source) pool(ctx context.Context) (*sql.DB, func(), error) {
+	if s.callerPools != nil {
+		callerPool, release, err := s.callerPools.Get(ctx, func(c sources.CallerCredentials) (any, error) {
+			pool, err := initMySQLConnectionPool(ctx, s.tracer, s.Name, s.Host, s.Port, c.User, c.Token, s.Database, s.QueryTimeout, s.QueryParams)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create pool for user %q: %w", c.User, err)
+			}
+			return pool, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return callerPool.(*sql.DB), release, nil
+	}
+	if s.Impersonation == nil {
+		return s.MySQLPool(), func() {}, nil
+	}
+	u, err := s.Impersonation.DatabaseUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	userPool, release, err := s.userPools.GetOrCreate(u.User, func() (any, error) {
+		return initMySQLConnectionPool(ctx, s.tracer, s.Name, s.Host, s.Port, u.User, u.Password, s.Database, s.QueryTimeout, s.QueryParams)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create pool for user %q: %w", u.User, err)
+	}
+	return userPool.(*sql.DB), release, nil
+}
+
+// DryRunSQL executes the statement in a transaction that is always rolled
+// back, and reports the rows it affects.
+func (s *Source) DryRunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return DryRunSQL(ctx, pool, statement, params)
+}
+
+// dryRunStatements lists the statements that can be rolled back. Other
+// statements, such as DDL, commit the transaction implicitly.
+var dryRunStatements = map[string]bool{
+	"SELECT": true, "WITH": true, "INSERT": true, "UPDATE": true, "DELETE": true, "REPLACE": true,
+}
+
+// DryRunSQL executes a single DML statement on the pool in a transaction
+// that is always rolled back, and reports the number of rows it affects.
+func DryRunSQL(ctx context.Context, pool *sql.DB, statement string, params []any) (any, error) {
+	keyword := sources.StatementKeyword(statement)
+	if !dryRunStatements[keyword] {
+		return nil, fmt.Errorf("%s statements can't be dry run, as they can't be rolled back", keyword)
+	}
+	if !sources.IsSingleStatement(SourceType, statement) {
+		return nil, fmt.Errorf("only a single statement can be dry run")
+	}
+
+	tx, err := pool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	result, err := tx.ExecContext(ctx, statement, params...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get rows affected: %w", err)
+	}
+	return map[string]any{
+		"dry_run":       true,
+		"command":       keyword,
+		"rows_affected": rowsAffected,
+	}, nil
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return runSQL(ctx, pool, statement, params)
+}
+
+// ReadOnlySQL executes a read-only statement in a read-only transaction.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	pool, release, err := s.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return ReadOnlySQL(ctx, pool, statement, params)
+}
+
+// ReadOnlySQL executes the statement on the pool in a read-only transaction.
+// Statements that aren't read-only are rejected first, as statements such as
+// DDL commit the transaction implicitly.
+func ReadOnlySQL(ctx context.Context, pool *sql.DB, statement string, params []any) (any, error) {
+	if !sources.IsReadOnlyStatement(SourceType, statement) {
+		return nil, fmt.Errorf("only a single read-only statement, such as SELECT, can be executed")
+	}
+	tx, err := pool.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	return runSQL(ctx, tx, statement, params)
+}
+
+// querier is implemented by pools and transactions
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, statement string, params []any) (any, error) {
+	results, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
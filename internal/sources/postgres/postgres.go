@@ -58,6 +58,12 @@ type Config struct {
 	Database      string            `yaml:"database" validate:"required"`
 	QueryParams   map[string]string `yaml:"queryParams"`
 	QueryExecMode string            `yaml:"queryExecMode" validate:"omitempty,oneof=cache_statement cache_describe describe_exec exec simple_protocol"`
+	// Impersonation, if set, runs queries as the database user mapped from a
+	// claim of the caller.
+	Impersonation *sources.ImpersonationConfig `yaml:"impersonation"`
+	// TokenExchange, if set, connects as the caller with a credential
+	// exchanged for their token, used as the password of their user.
+	TokenExchange *sources.TokenExchangeConfig `yaml:"tokenExchange"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -65,6 +71,15 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if r.Impersonation != nil && r.TokenExchange != nil {
+		return nil, fmt.Errorf("impersonation and tokenExchange can't be used together")
+	}
+	if r.Impersonation != nil {
+		if err := r.Impersonation.Validate(SourceType, sources.ImpersonationModeRole, sources.ImpersonationModePool); err != nil {
+			return nil, err
+		}
+	}
+
 	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.QueryExecMode)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
@@ -78,6 +93,19 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		tracer: tracer,
+	}
+	closePool := func(_ string, value any) {
+		value.(*pgxpool.Pool).Close()
+	}
+	if r.Impersonation != nil && r.Impersonation.Mode == sources.ImpersonationModePool {
+		s.userPools = sources.NewLRUCache(r.Impersonation.MaxPools, closePool)
+	}
+	if r.TokenExchange != nil {
+		s.callerPools, err = r.TokenExchange.Initialize("email", closePool)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return s, nil
 }
@@ -86,7 +114,13 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Pool *pgxpool.Pool
+	Pool   *pgxpool.Pool
+	tracer trace.Tracer
+	// userPools holds the connection pools of impersonated users
+	userPools *sources.LRUCache
+	// callerPools holds the connection pools of callers connecting with
+	// exchanged credentials
+	callerPools *sources.CallerPools
 }
 
 func (s *Source) SourceType() string {
@@ -101,8 +135,221 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// run modes of a statement
+const (
+	runDefault = iota
+	runDryRun
+	runReadOnly
+)
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.PostgresPool().Query(ctx, statement, params...)
+	return s.run(ctx, statement, params, runDefault)
+}
+
+// DryRunSQL executes the statement in a transaction that is always rolled
+// back, and reports the rows it affects.
+func (s *Source) DryRunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	return s.run(ctx, statement, params, runDryRun)
+}
+
+// ReadOnlySQL executes the statement in a read-only transaction.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	return s.run(ctx, statement, params, runReadOnly)
+}
+
+// RunSQLBatch executes the statement once for each set of params, in a
+// single transaction, and returns the rows of every execution.
+func (s *Source) RunSQLBatch(ctx context.Context, statement string, batch [][]any) (any, error) {
+	pool, role, release, err := s.callerPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return RunSQLBatch(ctx, pool, role, statement, batch)
+}
+
+// ConnectsAsCaller returns true if queries run with the credentials of the
+// caller instead of those of the source.
+func (s *Source) ConnectsAsCaller() bool {
+	return s.Impersonation != nil || s.callerPools != nil
+}
+
+// ImpersonationMode returns the mode used to impersonate callers, if any.
+func (s *Source) ImpersonationMode() string {
+	if s.Impersonation == nil {
+		return ""
+	}
+	return s.Impersonation.Mode
+}
+
+// callerPool returns the connection pool of the caller, and the role to
+// switch to if the caller is impersonated through a role. The returned func
+// releases the pool once the caller is done with it.
+func (s *Source) callerPool(ctx context.Context) (*pgxpool.Pool, string, func(), error) {
+	if s.callerPools != nil {
+		callerPool, release, err := s.callerPools.Get(ctx, func(c sources.CallerCredentials) (any, error) {
+			pool, err := initPostgresConnectionPool(ctx, s.tracer, s.Name, s.Host, s.Port, c.User, c.Token, s.Database, s.QueryParams, s.QueryExecMode)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create pool for user %q: %w", c.User, err)
+			}
+			return pool, nil
+		})
+		if err != nil {
+			return nil, "", nil, err
+		}
+		return callerPool.(*pgxpool.Pool), "", release, nil
+	}
+	if s.Impersonation == nil {
+		return s.PostgresPool(), "", func() {}, nil
+	}
+	u, err := s.Impersonation.DatabaseUser(ctx)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if s.Impersonation.Mode != sources.ImpersonationModePool {
+		return s.PostgresPool(), u.User, func() {}, nil
+	}
+	userPool, release, err := s.userPools.GetOrCreate(u.User, func() (any, error) {
+		return initPostgresConnectionPool(ctx, s.tracer, s.Name, s.Host, s.Port, u.User, u.Password, s.Database, s.QueryParams, s.QueryExecMode)
+	})
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("unable to create pool for user %q: %w", u.User, err)
+	}
+	return userPool.(*pgxpool.Pool), "", release, nil
+}
+
+func (s *Source) run(ctx context.Context, statement string, params []any, mode int) (any, error) {
+	pool, role, release, err := s.callerPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	switch mode {
+	case runDryRun:
+		return DryRunSQL(ctx, pool, role, statement, params)
+	case runReadOnly:
+		return ReadOnlySQL(ctx, pool, role, statement, params)
+	}
+	if role == "" {
+		return runSQL(ctx, pool, statement, params)
+	}
+	return inTx(ctx, pool, pgx.TxOptions{}, role, true, func(tx pgx.Tx) (any, error) {
+		return runSQL(ctx, tx, statement, params)
+	})
+}
+
+// inTx runs f in a transaction of the pool, switching to role first if it is
+// set. The transaction is rolled back unless commit is true.
+func inTx(ctx context.Context, pool *pgxpool.Pool, opts pgx.TxOptions, role string, commit bool, f func(pgx.Tx) (any, error)) (any, error) {
+	tx, err := pool.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	// SET LOCAL only lasts until the end of the transaction, so the role
+	// never leaks to other requests sharing the connection
+	if role != "" {
+		if _, err := tx.Exec(ctx, "SET LOCAL ROLE "+pgx.Identifier{role}.Sanitize()); err != nil {
+			return nil, fmt.Errorf("unable to set role %q: %w", role, err)
+		}
+	}
+	out, err := f(tx)
+	if err != nil {
+		return nil, err
+	}
+	if commit {
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("unable to commit transaction: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// transactionControl lists the statements that would end the transaction
+// of a dry run, a read-only statement or a batch.
+var transactionControl = map[string]bool{
+	"BEGIN": true, "START": true, "COMMIT": true, "END": true, "ROLLBACK": true,
+	"ABORT": true, "SAVEPOINT": true, "RELEASE": true, "PREPARE": true,
+}
+
+// checkTransactional returns an error if the statement could escape the
+// transaction it runs in.
+func checkTransactional(statement string) error {
+	if keyword := sources.StatementKeyword(statement); transactionControl[keyword] {
+		return fmt.Errorf("%s statements can't run in a dry run, read-only or batch transaction", keyword)
+	}
+	if !sources.IsSingleStatement(SourceType, statement) {
+		return fmt.Errorf("only a single statement can run in a dry run, read-only or batch transaction")
+	}
+	return nil
+}
+
+// DryRunSQL executes the statement on the pool in a transaction that is
+// always rolled back, switching to role first if it is set. It reports the
+// command tag and the number of rows affected by the statement.
+func DryRunSQL(ctx context.Context, pool *pgxpool.Pool, role, statement string, params []any) (any, error) {
+	if err := checkTransactional(statement); err != nil {
+		return nil, err
+	}
+	return inTx(ctx, pool, pgx.TxOptions{}, role, false, func(tx pgx.Tx) (any, error) {
+		results, err := tx.Query(ctx, statement, params...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		for results.Next() {
+		}
+		results.Close()
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		tag := results.CommandTag()
+		return map[string]any{
+			"dry_run":       true,
+			"command":       tag.String(),
+			"rows_affected": tag.RowsAffected(),
+		}, nil
+	})
+}
+
+// ReadOnlySQL executes the statement on the pool in a read-only transaction,
+// switching to role first if it is set.
+func ReadOnlySQL(ctx context.Context, pool *pgxpool.Pool, role, statement string, params []any) (any, error) {
+	if err := checkTransactional(statement); err != nil {
+		return nil, err
+	}
+	return inTx(ctx, pool, pgx.TxOptions{AccessMode: pgx.ReadOnly}, role, false, func(tx pgx.Tx) (any, error) {
+		return runSQL(ctx, tx, statement, params)
+	})
+}
+
+// RunSQLBatch executes the statement on the pool once for each set of
+// params, in a single transaction, switching to role first if it is set. The
+// rows of every execution are returned together.
+func RunSQLBatch(ctx context.Context, pool *pgxpool.Pool, role, statement string, batch [][]any) (any, error) {
+	if err := checkTransactional(statement); err != nil {
+		return nil, err
+	}
+	return inTx(ctx, pool, pgx.TxOptions{}, role, true, func(tx pgx.Tx) (any, error) {
+		var out []any
+		for i, params := range batch {
+			rows, err := runSQL(ctx, tx, statement, params)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i, err)
+			}
+			out = append(out, rows.([]any)...)
+		}
+		return out, nil
+	})
+}
+
+// querier is implemented by pools and transactions
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, statement string, params []any) (any, error) {
+	results, err := q.Query(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -115,6 +115,41 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "example with impersonation",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			impersonation:
+				authService: my-auth
+				claim: email
+				users:
+					alice@example.com:
+						user: alice
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					Impersonation: &sources.ImpersonationConfig{
+						AuthService: "my-auth",
+						Claim:       "email",
+						Users:       map[string]sources.DatabaseUser{"alice@example.com": {User: "alice"}},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
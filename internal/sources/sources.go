@@ -16,6 +16,8 @@ package sources
 
 import (
 	"context"
+	"maps"
+	"slices"
 
 	"fmt"
 
@@ -40,6 +42,11 @@ func Register(sourceType string, factory SourceConfigFactory) bool {
 	return true
 }
 
+// RegisteredTypes returns the sorted list of registered source types.
+func RegisteredTypes() []string {
+	return slices.Sorted(maps.Keys(sourceRegistry))
+}
+
 // DecodeConfig decodes a source configuration using the registered factory for the given type.
 func DecodeConfig(ctx context.Context, sourceType string, name string, decoder *yaml.Decoder) (SourceConfig, error) {
 	factory, found := sourceRegistry[sourceType]
@@ -17,6 +17,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 
@@ -94,8 +95,42 @@ func (s *Source) SQLiteDB() *sql.DB {
 }
 
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	return runSQL(ctx, s.SQLiteDB(), statement, params)
+}
+
+// ReadOnlySQL executes a single read-only statement on a connection with
+// `query_only` enabled, so that it can't modify the database. Statements such
+// as PRAGMA, which could disable `query_only`, are rejected first.
+func (s *Source) ReadOnlySQL(ctx context.Context, statement string, params []any) (any, error) {
+	if !sources.IsReadOnlyStatement(SourceType, statement) {
+		return nil, fmt.Errorf("only a single read-only statement, such as SELECT, can be executed")
+	}
+	conn, err := s.SQLiteDB().Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, fmt.Errorf("unable to enable query_only: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = OFF"); err != nil {
+			// never return a read-only connection to the pool
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+	return runSQL(ctx, conn, statement, params)
+}
+
+// querier is implemented by pools and connections
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func runSQL(ctx context.Context, q querier, statement string, params []any) (any, error) {
 	// Execute the SQL query with parameters
-	rows, err := s.SQLiteDB().QueryContext(ctx, statement, params...)
+	rows, err := q.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
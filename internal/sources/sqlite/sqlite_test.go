@@ -16,6 +16,7 @@ package sqlite_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -101,3 +103,33 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestReadOnlySQL(t *testing.T) {
+	ctx := context.Background()
+	cfg := sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: filepath.Join(t.TempDir(), "test.db")}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	s := src.(*sqlite.Source)
+	if _, err := s.RunSQL(ctx, "CREATE TABLE t (id INTEGER)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	if _, err := s.ReadOnlySQL(ctx, "SELECT * FROM t", nil); err != nil {
+		t.Errorf("unexpected error for read-only statement: %s", err)
+	}
+	if _, err := s.ReadOnlySQL(ctx, "INSERT INTO t VALUES (1)", nil); err == nil {
+		t.Errorf("expected error for write statement")
+	}
+	if _, err := s.ReadOnlySQL(ctx, "SELECT 1; INSERT INTO t VALUES (1)", nil); err == nil {
+		t.Errorf("expected error for multiple statements")
+	}
+	if _, err := s.ReadOnlySQL(ctx, "PRAGMA query_only = OFF", nil); err == nil {
+		t.Errorf("expected error for pragma statement")
+	}
+	// query_only is disabled once the read-only statement is done
+	if _, err := s.RunSQL(ctx, "INSERT INTO t VALUES (1)", nil); err != nil {
+		t.Errorf("unexpected error for write statement: %s", err)
+	}
+}
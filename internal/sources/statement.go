@@ -0,0 +1,271 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// StatementKeyword returns the first keyword of a SQL statement in upper
+// case, skipping leading whitespace and comments.
+func StatementKeyword(statement string) string {
+	s := strings.TrimSpace(statement)
+	for {
+		switch {
+		case strings.HasPrefix(s, "--"), strings.HasPrefix(s, "#"):
+			_, rest, _ := strings.Cut(s, "\n")
+			s = strings.TrimSpace(rest)
+		case strings.HasPrefix(s, "/*"):
+			_, rest, _ := strings.Cut(s, "*/")
+			s = strings.TrimSpace(rest)
+		default:
+			end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+			if end < 0 {
+				end = len(s)
+			}
+			return strings.ToUpper(s[:end])
+		}
+	}
+}
+
+// IsSingleStatement returns true if the statement of the dialect doesn't
+// contain a semicolon followed by another statement, outside of comments,
+// literals and quoted identifiers. It can't detect the statements of an
+// mssql batch, which don't need to be separated by semicolons.
+func IsSingleStatement(dialect, statement string) bool {
+	_, single := statementWords(dialect, statement)
+	return single
+}
+
+// readStatements lists the statements that only read data, by dialect. The
+// sources of mysql, sqlite, clickhouse and firebird also run read-only
+// statements in a read-only mode of the database, where the classification
+// only rejects early the statements that would escape it, such as DDL
+// committing the transaction implicitly. The other dialects have no such
+// mode, so the classification is the only check of their read-only tools.
+var readStatements = map[string][]string{
+	"mysql":       {"SELECT", "WITH", "VALUES", "TABLE", "SHOW", "DESCRIBE", "DESC", "EXPLAIN"},
+	"sqlite":      {"SELECT", "WITH", "VALUES", "EXPLAIN"},
+	"clickhouse":  {"SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXPLAIN", "EXISTS"},
+	"firebird":    {"SELECT", "WITH"},
+	"mssql":       {"SELECT", "WITH"},
+	"trino":       {"SELECT", "WITH", "VALUES", "TABLE", "SHOW", "DESCRIBE", "EXPLAIN"},
+	"snowflake":   {"SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXPLAIN"},
+	"tidb":        {"SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXPLAIN"},
+	"oceanbase":   {"SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXPLAIN"},
+	"singlestore": {"SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXPLAIN"},
+	"mindsdb":     {"SELECT", "WITH", "SHOW", "DESCRIBE", "DESC"},
+}
+
+// mysqlSyntax lists the dialects sharing the comments and string escapes of
+// mysql.
+var mysqlSyntax = map[string]bool{
+	"mysql": true, "tidb": true, "oceanbase": true, "singlestore": true, "mindsdb": true,
+}
+
+// writeKeywords are rejected anywhere in a read-only statement, e.g. in
+// data-modifying CTEs or `SELECT ... INTO OUTFILE`, which writes a file even
+// in a read-only transaction.
+var writeKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "UPSERT": true,
+	"CREATE": true, "DROP": true, "ALTER": true, "TRUNCATE": true, "RENAME": true,
+	"GRANT": true, "REVOKE": true, "INTO": true, "CALL": true, "EXEC": true,
+	"EXECUTE": true, "COPY": true, "LOAD": true, "ATTACH": true, "DETACH": true,
+	"PRAGMA": true, "VACUUM": true, "LOCK": true, "UNLOCK": true, "ANALYZE": true,
+	"OUTFILE": true, "DUMPFILE": true,
+}
+
+// mssqlKeywords are also rejected anywhere in a read-only mssql statement.
+// T-SQL doesn't require semicolons between the statements of a batch, so
+// the keywords starting a statement are rejected after the first word,
+// except SELECT and WITH, which also start subqueries and table hints. The
+// functions running a query on another server are rejected as well, as the
+// query they run can write.
+var mssqlKeywords = map[string]bool{
+	"DECLARE": true, "SET": true, "USE": true, "DENY": true, "BEGIN": true,
+	"COMMIT": true, "ROLLBACK": true, "SAVE": true, "PRINT": true, "RAISERROR": true,
+	"THROW": true, "WAITFOR": true, "GOTO": true, "IF": true, "WHILE": true,
+	"RETURN": true, "SHUTDOWN": true, "KILL": true, "BACKUP": true, "RESTORE": true,
+	"DBCC": true, "RECONFIGURE": true, "BULK": true, "CHECKPOINT": true, "SEND": true,
+	"RECEIVE": true, "REVERT": true, "SETUSER": true, "ENABLE": true, "DISABLE": true,
+	"WRITETEXT": true, "UPDATETEXT": true, "READTEXT": true, "OPENQUERY": true,
+	"OPENROWSET": true, "OPENDATASOURCE": true,
+}
+
+// IsReadOnlyStatement returns true if the statement of the dialect only
+// reads data. It must be a single statement starting with a read statement
+// of the dialect, with no write keyword outside of comments, literals and
+// quoted identifiers. The classification is conservative, e.g. it rejects
+// `SELECT ... FOR UPDATE`, and it returns false for the dialects it doesn't
+// support. Where the database has a read-only mode, the statement must also
+// run in it, as the classification can't tell what functions called by the
+// statement do.
+func IsReadOnlyStatement(dialect, statement string) bool {
+	allowed, ok := readStatements[dialect]
+	if !ok {
+		return false
+	}
+	words, single := statementWords(dialect, statement)
+	if !single || len(words) == 0 {
+		return false
+	}
+	if !slices.Contains(allowed, words[0]) {
+		return false
+	}
+	for _, w := range words[1:] {
+		if writeKeywords[w] || dialect == "mssql" && mssqlKeywords[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// statementWords returns the upper case words of the statement, skipping
+// comments, literals and quoted identifiers. It also reports whether the
+// statement is a single statement.
+func statementWords(dialect, statement string) ([]string, bool) {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, strings.ToUpper(word.String()))
+			word.Reset()
+		}
+	}
+	// skipQuoted skips a quoted section starting at i, returning the index
+	// of its closing quote
+	skipQuoted := func(i int, closing byte, backslash bool) int {
+		for j := i + 1; j < len(statement); j++ {
+			switch {
+			case backslash && statement[j] == '\\':
+				j++
+			case statement[j] == closing:
+				// doubled quotes are escaped quotes
+				if j+1 < len(statement) && statement[j+1] == closing {
+					j++
+					continue
+				}
+				return j
+			}
+		}
+		return len(statement)
+	}
+
+	// dollarQuote returns the delimiter of the dollar-quoted string starting
+	// at i, such as `$$` or `$tag$` in postgres, or "" if there is none
+	dollarQuote := func(i int) string {
+		switch dialect {
+		case "postgres":
+		case "snowflake":
+			if strings.HasPrefix(statement[i:], "$$") {
+				return "$$"
+			}
+			return ""
+		default:
+			return ""
+		}
+		for j := i + 1; j < len(statement); j++ {
+			c := statement[j]
+			switch {
+			case c == '$':
+				return statement[i : j+1]
+			case c == '_' || c >= 0x80 || unicode.IsLetter(rune(c)):
+			case unicode.IsDigit(rune(c)) && j > i+1:
+			default:
+				// e.g. a positional param such as $1
+				return ""
+			}
+		}
+		return ""
+	}
+
+	ended := false
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		// `$` can be part of an identifier, but not start it
+		isWordChar := c == '_' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) || c == '$' && word.Len() > 0
+		// the word before a quote, e.g. the E of a postgres E'' string
+		prefix := word.String()
+		if !isWordChar {
+			flush()
+		}
+		switch {
+		case strings.HasPrefix(statement[i:], "/*!") && mysqlSyntax[dialect]:
+			// the content of mysql executable comments is executed, so it is
+			// read as part of the statement
+			i += 2
+		case isLineComment(dialect, statement[i:]):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				return words, true
+			}
+			i += end
+		case strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				return words, true
+			}
+			i += end + 3
+		case c == ';':
+			ended = true
+		case unicode.IsSpace(rune(c)):
+		case ended:
+			// anything but comments after a semicolon is another statement
+			return words, false
+		case isWordChar:
+			word.WriteByte(c)
+		case c == '\'':
+			// backslashes escape quotes in mysql and snowflake strings, and
+			// in postgres escape strings
+			backslash := mysqlSyntax[dialect] || dialect == "snowflake" || dialect == "postgres" && strings.EqualFold(prefix, "E")
+			i = skipQuoted(i, '\'', backslash)
+		case c == '$' && prefix == "" && dollarQuote(i) != "":
+			// dollar-quoted strings, which end at the same delimiter
+			delim := dollarQuote(i)
+			end := strings.Index(statement[i+len(delim):], delim)
+			if end < 0 {
+				return words, true
+			}
+			i += len(delim) + end + len(delim) - 1
+		case c == '"':
+			i = skipQuoted(i, '"', mysqlSyntax[dialect])
+		case c == '`' && dialect != "postgres":
+			i = skipQuoted(i, '`', false)
+		case c == '[' && dialect == "mssql":
+			i = skipQuoted(i, ']', false)
+		}
+	}
+	flush()
+	return words, true
+}
+
+// isLineComment returns true if s starts with a comment running to the end
+// of the line in the dialect. In mysql, `--` must be followed by whitespace,
+// e.g. `1--1` is a subtraction, and `#` also starts a comment. Snowflake
+// also accepts `//`.
+func isLineComment(dialect, s string) bool {
+	if dialect == "snowflake" && strings.HasPrefix(s, "//") {
+		return true
+	}
+	if !mysqlSyntax[dialect] {
+		return strings.HasPrefix(s, "--")
+	}
+	if strings.HasPrefix(s, "#") {
+		return true
+	}
+	return strings.HasPrefix(s, "--") && (len(s) == 2 || unicode.IsSpace(rune(s[2])))
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+func TestStatementKeyword(t *testing.T) {
+	tcs := []struct {
+		statement string
+		want      string
+	}{
+		{statement: "select 1", want: "SELECT"},
+		{statement: "  Update t SET a = 1", want: "UPDATE"},
+		{statement: "-- comment\nCOMMIT;", want: "COMMIT"},
+		{statement: "/* a */ /* b */ delete from t", want: "DELETE"},
+		{statement: "# comment\nreplace into t values (1)", want: "REPLACE"},
+		{statement: "(SELECT 1)", want: ""},
+		{statement: "", want: ""},
+	}
+	for _, tc := range tcs {
+		if got := sources.StatementKeyword(tc.statement); got != tc.want {
+			t.Errorf("StatementKeyword(%q) = %q, want %q", tc.statement, got, tc.want)
+		}
+	}
+}
+
+func TestIsSingleStatement(t *testing.T) {
+	tcs := []struct {
+		dialect   string
+		statement string
+		want      bool
+	}{
+		{dialect: "postgres", statement: "SELECT 1", want: true},
+		{dialect: "postgres", statement: "SELECT 1;  \n", want: true},
+		{dialect: "postgres", statement: "UPDATE t SET a = 1; DROP TABLE t", want: false},
+		{dialect: "postgres", statement: "SELECT ';' FROM t", want: true},
+		{dialect: "mysql", statement: "UPDATE t SET a = 1; # done", want: true},
+		{dialect: "postgres", statement: "UPDATE t SET a = 1; # done", want: false},
+		{dialect: "postgres", statement: "SELECT $$'$$; COMMIT; DELETE FROM t; --'", want: false},
+		{dialect: "postgres", statement: "SELECT $tag$ ; COMMIT; $tag$", want: true},
+		{dialect: "postgres", statement: "SELECT a$$x$ ; COMMIT; DELETE FROM t; SELECT a$$x$", want: false},
+		{dialect: "postgres", statement: "SELECT $1, $2; COMMIT", want: false},
+		{dialect: "postgres", statement: `SELECT E'\'; COMMIT; --'`, want: true},
+		{dialect: "postgres", statement: `SELECT 'a\'; COMMIT; --'`, want: false},
+		{dialect: "postgres", statement: "SELECT 1 `; COMMIT; --`", want: false},
+		{dialect: "mysql", statement: "SELECT 1 `; COMMIT; --`", want: true},
+	}
+	for _, tc := range tcs {
+		if got := sources.IsSingleStatement(tc.dialect, tc.statement); got != tc.want {
+			t.Errorf("IsSingleStatement(%q, %q) = %v, want %v", tc.dialect, tc.statement, got, tc.want)
+		}
+	}
+}
+
+func TestIsReadOnlyStatement(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		dialect   string
+		statement string
+		want      bool
+	}{
+		{desc: "select", dialect: "mysql", statement: "SELECT * FROM t WHERE a = 1;", want: true},
+		{desc: "show", dialect: "mysql", statement: "show tables", want: true},
+		{desc: "show not in dialect", dialect: "firebird", statement: "SHOW TABLES", want: false},
+		{desc: "cte", dialect: "clickhouse", statement: "WITH x AS (SELECT 1) SELECT * FROM x", want: true},
+		{desc: "data-modifying cte", dialect: "sqlite", statement: "WITH x AS (SELECT 1) DELETE FROM t", want: false},
+		{desc: "select into outfile", dialect: "mysql", statement: "SELECT * FROM t INTO OUTFILE '/tmp/t'", want: false},
+		{desc: "update", dialect: "sqlite", statement: "UPDATE t SET a = 1", want: false},
+		{desc: "pragma", dialect: "sqlite", statement: "PRAGMA query_only = OFF", want: false},
+		{desc: "leading comment", dialect: "mysql", statement: "# read\n/* all */ SELECT 1 -- rows", want: true},
+		{desc: "keyword in literal", dialect: "mysql", statement: "SELECT 'it''s a DELETE' FROM t", want: true},
+		{desc: "keyword in escaped literal", dialect: "mysql", statement: `SELECT 'it\'s; DROP TABLE t' FROM t`, want: true},
+		{desc: "keyword in quoted identifier", dialect: "sqlite", statement: "SELECT `update`, \"insert\" FROM t", want: true},
+		{desc: "multiple statements", dialect: "mysql", statement: "SELECT 1; DROP TABLE t", want: false},
+		{desc: "trailing comment", dialect: "clickhouse", statement: "SELECT 1; -- done", want: true},
+		{desc: "explain analyze", dialect: "mysql", statement: "EXPLAIN ANALYZE DELETE FROM t", want: false},
+		{desc: "mysql executable comment", dialect: "mysql", statement: "SELECT * FROM t /*! INTO OUTFILE '/tmp/t' */", want: false},
+		{desc: "mysql double dash without space", dialect: "mysql", statement: "SELECT 1--1 INTO OUTFILE '/tmp/t'", want: false},
+		{desc: "show not in mssql", dialect: "mssql", statement: "SHOW TABLES", want: false},
+		{desc: "mssql select into", dialect: "mssql", statement: "SELECT * INTO t2 FROM t", want: false},
+		{desc: "mssql bracket identifier", dialect: "mssql", statement: "SELECT [update; DROP TABLE t] FROM t", want: true},
+		{desc: "mssql subquery", dialect: "mssql", statement: "WITH x AS (SELECT 1 AS a) SELECT * FROM x WITH (NOLOCK) WHERE a IN (SELECT 1)", want: true},
+		{desc: "mssql batch without semicolon", dialect: "mssql", statement: "SELECT 1 DELETE FROM t", want: false},
+		{desc: "mssql shutdown", dialect: "mssql", statement: "SELECT 1 SHUTDOWN WITH NOWAIT", want: false},
+		{desc: "mssql openquery", dialect: "mssql", statement: "SELECT * FROM OPENQUERY(srv, 'DELETE FROM t')", want: false},
+		{desc: "mssql openrowset", dialect: "mssql", statement: "SELECT * FROM OPENROWSET('SQLNCLI', 'Server=srv;', 'DELETE FROM t')", want: false},
+		{desc: "mssql opendatasource", dialect: "mssql", statement: "SELECT * FROM OPENDATASOURCE('SQLNCLI', 'Data Source=srv').db.dbo.t", want: false},
+		{desc: "mssql exec", dialect: "mssql", statement: "SELECT 1 EXEC('DELETE FROM t')", want: false},
+		{desc: "mssql dbcc", dialect: "mssql", statement: "SELECT 1 DBCC SHRINKDATABASE(db)", want: false},
+		{desc: "mssql kill", dialect: "mssql", statement: "SELECT 1 KILL 52", want: false},
+		{desc: "mssql backup", dialect: "mssql", statement: "SELECT 1 BACKUP DATABASE db TO DISK = 'x.bak'", want: false},
+		{desc: "mssql restore", dialect: "mssql", statement: "SELECT 1 RESTORE DATABASE db FROM DISK = 'x.bak'", want: false},
+		{desc: "mssql declare", dialect: "mssql", statement: "SELECT 1 DECLARE @sql nvarchar(max)", want: false},
+		{desc: "mssql set", dialect: "mssql", statement: "SELECT 1 SET ANSI_NULLS OFF", want: false},
+		{desc: "mssql deny", dialect: "mssql", statement: "SELECT 1 DENY SELECT ON t TO u", want: false},
+		{desc: "mssql reconfigure", dialect: "mssql", statement: "SELECT 1 RECONFIGURE", want: false},
+		{desc: "trino cte", dialect: "trino", statement: "WITH x AS (SELECT 1) SELECT * FROM x", want: true},
+		{desc: "trino explain analyze", dialect: "trino", statement: "EXPLAIN ANALYZE INSERT INTO t SELECT 1", want: false},
+		{desc: "snowflake dollar string", dialect: "snowflake", statement: "SELECT $$; DELETE FROM t$$", want: true},
+		{desc: "snowflake slash comment", dialect: "snowflake", statement: "SELECT 1 // DELETE FROM t", want: true},
+		{desc: "tidb executable comment", dialect: "tidb", statement: "SELECT * FROM t /*! INTO OUTFILE '/tmp/t' */", want: false},
+		{desc: "singlestore escaped literal", dialect: "singlestore", statement: `SELECT 'it\'s; DROP TABLE t' FROM t`, want: true},
+		{desc: "mindsdb show", dialect: "mindsdb", statement: "SHOW DATABASES", want: true},
+		{desc: "oceanbase update", dialect: "oceanbase", statement: "UPDATE t SET a = 1", want: false},
+		{desc: "unknown dialect", dialect: "other", statement: "SELECT 1", want: false},
+		{desc: "empty", dialect: "mysql", statement: " ; ", want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := sources.IsReadOnlyStatement(tc.dialect, tc.statement); got != tc.want {
+				t.Errorf("IsReadOnlyStatement(%q, %q) = %v, want %v", tc.dialect, tc.statement, got, tc.want)
+			}
+		})
+	}
+}
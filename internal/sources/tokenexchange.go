@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/tokenexchange"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// TokenExchangeConfig connects to the database on behalf of the caller, with
+// a credential exchanged for the verified token of the caller, such as a
+// database IAM token.
+type TokenExchangeConfig struct {
+	tokenexchange.Config `yaml:",inline"`
+	// UserClaim is the claim of the caller's token holding the database user
+	// to connect as.
+	UserClaim string `yaml:"userClaim"`
+	// MaxPools is the number of per-caller connection pools kept open. The
+	// least recently used pool is closed when it is full.
+	MaxPools int `yaml:"maxPools" validate:"gte=0"`
+}
+
+// callerConnector is implemented by sources that can run queries with the
+// credentials of the caller, through impersonation or token exchange.
+type callerConnector interface {
+	ConnectsAsCaller() bool
+}
+
+// ConnectsAsCaller returns true if the source runs queries with the
+// credentials of the caller. Tools must then go through the methods of the
+// source, not its shared pool, which uses the credentials of the source.
+func ConnectsAsCaller(src any) bool {
+	s, ok := src.(callerConnector)
+	return ok && s.ConnectsAsCaller()
+}
+
+// CallerCredentials are the credentials a source connects with on behalf of
+// the caller.
+type CallerCredentials struct {
+	// User is the value of the user claim of the caller, if any.
+	User string
+	// Token is the credential exchanged for the caller's token.
+	Token string
+}
+
+// Initialize returns the connection pools of the callers. The user is read
+// from the userClaim of the caller, or from defaultUserClaim if it isn't set.
+// Sources that don't connect as a named user pass an empty defaultUserClaim.
+// onEvict closes the pools evicted from the cache.
+func (c TokenExchangeConfig) Initialize(defaultUserClaim string, onEvict OnEvictFunc) (*CallerPools, error) {
+	exchanger, err := c.Config.Initialize()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tokenExchange: %w", err)
+	}
+	if c.UserClaim == "" {
+		c.UserClaim = defaultUserClaim
+	}
+	if c.MaxPools == 0 {
+		c.MaxPools = defaultMaxPools
+	}
+	return &CallerPools{
+		cfg:       c,
+		exchanger: exchanger,
+		pools:     NewLRUCache(c.MaxPools, onEvict),
+	}, nil
+}
+
+// CallerPools holds a connection pool per caller and exchanged credential,
+// so that connections are never shared between callers.
+type CallerPools struct {
+	cfg       TokenExchangeConfig
+	exchanger *tokenexchange.Exchanger
+	pools     *LRUCache
+}
+
+// Get returns the connection pool of the caller in ctx, created with the
+// credentials of the caller if it doesn't exist yet. The returned func
+// releases the pool once the caller is done with it.
+func (p *CallerPools) Get(ctx context.Context, create func(CallerCredentials) (any, error)) (any, func(), error) {
+	var user string
+	if subject, ok := auth.SubjectTokenFromContext(ctx, p.cfg.AuthService); ok && p.cfg.UserClaim != "" {
+		user, _ = subject.Claims[p.cfg.UserClaim].(string)
+		if user == "" {
+			return nil, nil, util.NewCallerError(
+				fmt.Sprintf("claim %q is missing from the token of auth service %q", p.cfg.UserClaim, p.cfg.AuthService),
+				http.StatusForbidden,
+				nil,
+			)
+		}
+	}
+	// a missing token of the caller is reported by the exchange
+	token, err := p.exchanger.Token(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// a new pool is created when the credential of the caller is renewed,
+	// and the pools of expired credentials are evicted in turn
+	creds := CallerCredentials{User: user, Token: token}
+	sum := sha256.Sum256([]byte(user + "\x00" + token))
+	return p.pools.GetOrCreate(hex.EncodeToString(sum[:]), func() (any, error) {
+		return create(creds)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/tokenexchange"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestCallerPools(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		subject := r.PostForm.Get("subject_token")
+		if subject == "revoked-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "db-" + subject,
+			"issued_token_type": tokenexchange.AccessTokenType,
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		})
+	}))
+	defer server.Close()
+
+	cfg := sources.TokenExchangeConfig{
+		Config: tokenexchange.Config{TokenEndpoint: server.URL, AuthService: "my-auth"},
+	}
+	pools, err := cfg.Initialize("email", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	withToken := func(token string, claims map[string]any) context.Context {
+		return auth.WithSubjectTokens(context.Background(), map[string]auth.SubjectToken{"my-auth": {Token: token, Claims: claims}})
+	}
+	var created int
+	create := func(c sources.CallerCredentials) (any, error) {
+		created++
+		return c, nil
+	}
+
+	alice := withToken("alice-token", map[string]any{"email": "alice@example.com"})
+	for range 2 {
+		got, release, err := pools.Get(alice, create)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		release()
+		want := sources.CallerCredentials{User: "alice@example.com", Token: "db-alice-token"}
+		if got != want {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	}
+	if created != 1 {
+		t.Errorf("expected the pool of the caller to be reused, created %d", created)
+	}
+
+	tcs := []struct {
+		desc string
+		ctx  context.Context
+		code int
+	}{
+		{desc: "no token", ctx: context.Background(), code: http.StatusUnauthorized},
+		{desc: "missing claim", ctx: withToken("bob-token", map[string]any{"sub": "bob"}), code: http.StatusForbidden},
+		{desc: "rejected token", ctx: withToken("revoked-token", map[string]any{"email": "eve@example.com"}), code: http.StatusForbidden},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, err := pools.Get(tc.ctx, create)
+			var csErr *util.ClientServerError
+			if !errors.As(err, &csErr) || csErr.Code != tc.code || !csErr.Caller {
+				t.Fatalf("expected caller error with code %d, got %v", tc.code, err)
+			}
+		})
+	}
+}
@@ -89,22 +89,28 @@ func GetIAMPrincipalEmailFromADC(ctx context.Context, dbType string) (string, er
 		return "", fmt.Errorf("email field is not a string")
 	}
 
+	return FormatIAMUser(fullEmail, dbType)
+}
+
+// FormatIAMUser formats the email of an IAM principal as the name of its
+// database user.
+func FormatIAMUser(email, dbType string) (string, error) {
 	var username string
 	// Format the username based on Database Type
 	switch strings.ToLower(dbType) {
 	case "mysql":
-		username, _, _ = strings.Cut(fullEmail, "@")
+		username, _, _ = strings.Cut(email, "@")
 
 	case "postgres":
 		// service account email used for IAM should trim the suffix
-		username = strings.TrimSuffix(fullEmail, ".gserviceaccount.com")
+		username = strings.TrimSuffix(email, ".gserviceaccount.com")
 
 	default:
 		return "", fmt.Errorf("unsupported dbType: %s. Use 'mysql' or 'postgres'", dbType)
 	}
 
 	if username == "" {
-		return "", fmt.Errorf("username from ADC cannot be an empty string")
+		return "", fmt.Errorf("username cannot be an empty string")
 	}
 
 	return username, nil
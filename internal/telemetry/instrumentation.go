@@ -31,6 +31,8 @@ const (
 	mcpSessionDurationName    = "mcp.server.session.duration"
 	mcpActiveSessionsName     = "toolbox.server.mcp.active_sessions"
 	toolExecutionDurationName = "toolbox.tool.execution.duration"
+	embeddingCacheHitsName    = "toolbox.embedding.cache.hits"
+	embeddingCacheMissesName  = "toolbox.embedding.cache.misses"
 )
 
 // Instrumentation defines the telemetry instrumentation for toolbox
@@ -41,6 +43,8 @@ type Instrumentation struct {
 	McpSessionDuration    metric.Float64Histogram
 	McpActiveSessions     metric.Int64UpDownCounter
 	ToolExecutionDuration metric.Float64Histogram
+	EmbeddingCacheHits    metric.Int64Counter
+	EmbeddingCacheMisses  metric.Int64Counter
 }
 
 func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, error) {
@@ -87,6 +91,24 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		return nil, fmt.Errorf("unable to create %s metric: %w", toolExecutionDurationName, err)
 	}
 
+	embeddingCacheHits, err := meter.Int64Counter(
+		embeddingCacheHitsName,
+		metric.WithDescription("Number of texts embedded from the embedding cache."),
+		metric.WithUnit("{text}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", embeddingCacheHitsName, err)
+	}
+
+	embeddingCacheMisses, err := meter.Int64Counter(
+		embeddingCacheMissesName,
+		metric.WithDescription("Number of texts missing from the embedding cache."),
+		metric.WithUnit("{text}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", embeddingCacheMissesName, err)
+	}
+
 	instrumentation := &Instrumentation{
 		Tracer:                tracer,
 		meter:                 meter,
@@ -94,6 +116,8 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		McpSessionDuration:    mcpSessionDuration,
 		McpActiveSessions:     mcpActiveSessions,
 		ToolExecutionDuration: toolExecutionDuration,
+		EmbeddingCacheHits:    embeddingCacheHits,
+		EmbeddingCacheMisses:  embeddingCacheMisses,
 	}
 	return instrumentation, nil
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
@@ -176,6 +177,15 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 				}
 			}
 			lowLevelParam.ParameterValue.ArrayValues = arrayValues
+		} else if vector, ok := value.([]float64); ok {
+			// Embedded parameters hold their vector as an ARRAY<FLOAT64>.
+			lowLevelParam.ParameterType.Type = "ARRAY"
+			lowLevelParam.ParameterType.ArrayType = &bigqueryrestapi.QueryParameterType{Type: "FLOAT64"}
+			arrayValues := make([]*bigqueryrestapi.QueryParameterValue, len(vector))
+			for i, f := range vector {
+				arrayValues[i] = &bigqueryrestapi.QueryParameterValue{Value: strconv.FormatFloat(f, 'g', -1, 64)}
+			}
+			lowLevelParam.ParameterValue.ArrayValues = arrayValues
 		} else {
 			// Handle scalar types based on their defined type.
 			bqType, err := bqutil.BQTypeStringFromToolType(p.GetType())
@@ -219,7 +229,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsFloatList)
 }
 
 func (t Tool) Manifest() tools.Manifest {
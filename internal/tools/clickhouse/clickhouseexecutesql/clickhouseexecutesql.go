@@ -45,6 +45,7 @@ func newExecuteSQLConfig(ctx context.Context, name string, decoder *yaml.Decoder
 
 type compatibleSource interface {
 	RunSQL(context.Context, string, parameters.ParamValues) (any, error)
+	ReadOnlySQL(context.Context, string, parameters.ParamValues) (any, error)
 }
 
 type Config struct {
@@ -53,6 +54,8 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly only runs read-only statements, such as SELECT.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -65,7 +68,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	sqlParameter := parameters.NewStringParameter("sql", "The SQL statement to execute.")
 	params := parameters.Parameters{sqlParameter}
 
-	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+	var annotations *tools.ToolAnnotations
+	if cfg.ReadOnly {
+		annotations = tools.NewReadOnlyAnnotations()
+	}
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, annotations)
 
 	t := Tool{
 		Config:      cfg,
@@ -100,7 +107,12 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if !ok {
 		return nil, util.NewAgentError(fmt.Sprintf("unable to cast sql parameter %s", paramsMap["sql"]), nil)
 	}
-	resp, err := source.RunSQL(ctx, sql, nil)
+	var resp any
+	if t.ReadOnly {
+		resp, err = source.ReadOnlySQL(ctx, sql, nil)
+	} else {
+		resp, err = source.RunSQL(ctx, sql, nil)
+	}
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
@@ -0,0 +1,257 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/jsonpath"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const resourceType string = "composite"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Step calls a tool with params built from the parameters of the composite
+// tool and the results of the previous steps.
+type Step struct {
+	Name   string         `yaml:"name" validate:"required"`
+	Tool   string         `yaml:"tool" validate:"required"`
+	Params map[string]any `yaml:"params"`
+	When   string         `yaml:"when"`
+}
+
+type Config struct {
+	Name         string                 `yaml:"name" validate:"required"`
+	Type         string                 `yaml:"type" validate:"required"`
+	Description  string                 `yaml:"description" validate:"required"`
+	Steps        []Step                 `yaml:"steps" validate:"required,min=1,dive"`
+	Result       any                    `yaml:"result"`
+	AuthRequired []string               `yaml:"authRequired"`
+	Parameters   parameters.Parameters  `yaml:"parameters"`
+	Annotations  *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(_ map[string]sources.Source) (tools.Tool, error) {
+	if err := parameters.CheckDuplicateParameters(cfg.Parameters); err != nil {
+		return nil, err
+	}
+
+	steps := make([]step, 0, len(cfg.Steps))
+	names := make(map[string]bool)
+	for _, s := range cfg.Steps {
+		if strings.ContainsAny(s.Name, ".[]") {
+			return nil, fmt.Errorf("invalid step name %q: must not contain \".\", \"[\" or \"]\"", s.Name)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("duplicate step name %q", s.Name)
+		}
+		names[s.Name] = true
+
+		params := make(objectValue, len(s.Params))
+		for k, v := range s.Params {
+			parsed, err := parseValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid param %q of step %q: %w", k, s.Name, err)
+			}
+			params[k] = parsed
+		}
+		parsed := step{Step: s, params: params}
+		if s.When != "" {
+			cond, err := parseCondition(s.When)
+			if err != nil {
+				return nil, fmt.Errorf("invalid condition of step %q: %w", s.Name, err)
+			}
+			parsed.when = cond
+		}
+		steps = append(steps, parsed)
+	}
+
+	var result value
+	if cfg.Result != nil {
+		var err error
+		result, err = parseValue(cfg.Result)
+		if err != nil {
+			return nil, fmt.Errorf("invalid result: %w", err)
+		}
+	}
+
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, cfg.Parameters, cfg.Annotations)
+
+	t := Tool{
+		Config:      cfg,
+		steps:       steps,
+		result:      result,
+		manifest:    tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: mcpManifest,
+	}
+	return t, nil
+}
+
+// step is a step with its parsed params and condition.
+type step struct {
+	Step
+	params objectValue
+	when   *condition
+}
+
+// callersKey holds the names of the composite tools being invoked.
+type callersKey struct{}
+
+// validate interface
+var _ tools.Tool = Tool{}
+var _ tools.ToolReferrer = Tool{}
+
+type Tool struct {
+	Config
+	steps       []step
+	result      value
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke runs the steps in order, on behalf of the caller. The data of the
+// invocation holds the parameters as `$.params` and the result of each step
+// run so far as `$.steps.<name>`. The result of the tool is built from them by
+// `result`, and defaults to the result of the last step run.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	run, ok := tools.ToolRunnerFromContext(ctx)
+	if !ok {
+		return nil, util.NewClientServerError("unable to run the steps of the tool", http.StatusInternalServerError, fmt.Errorf("no tool runner in context"))
+	}
+
+	// the tool references are checked when the config is loaded, this is only
+	// a safeguard against an unbounded recursion
+	callers, _ := ctx.Value(callersKey{}).([]string)
+	if slices.Contains(callers, t.Name) {
+		return nil, util.NewClientServerError(fmt.Sprintf("tool %q calls itself", t.Name), http.StatusInternalServerError, nil)
+	}
+	ctx = context.WithValue(ctx, callersKey{}, append(slices.Clip(callers), t.Name))
+
+	paramsData, err := jsonpath.Normalize(params.AsMap())
+	if err != nil {
+		return nil, util.NewAgentError("unable to read params", err)
+	}
+	results := make(map[string]any)
+	data := map[string]any{"params": paramsData, "steps": results}
+
+	var last any
+	for _, s := range t.steps {
+		if s.when != nil && !s.when.eval(data) {
+			continue
+		}
+		args, _ := s.params.eval(data).(map[string]any)
+		// leave out missing values, so that the params of the tool of the step
+		// fall back to their default
+		for k, v := range args {
+			if v == nil {
+				delete(args, k)
+			}
+		}
+
+		res, err := run(ctx, s.Tool, args)
+		if err != nil {
+			msg := fmt.Sprintf("step %q failed", s.Name)
+			var csErr *util.ClientServerError
+			if errors.As(err, &csErr) {
+				return nil, util.NewClientServerError(msg, csErr.Code, err)
+			}
+			return nil, util.NewAgentError(msg, err)
+		}
+		last, err = jsonpath.Normalize(res)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("unable to read the result of step %q", s.Name), err)
+		}
+		results[s.Name] = last
+	}
+
+	if t.result == nil {
+		return last, nil
+	}
+	return t.result.eval(data), nil
+}
+
+func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	return parameters.EmbedParams(ctx, t.Parameters, paramValues, embeddingModelsMap, nil)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
+
+func (t Tool) RequiresClientAuthorization(resourceMgr tools.SourceProvider) (bool, error) {
+	// the steps check the authorization of their own tools
+	return false, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Config
+}
+
+func (t Tool) GetAuthTokenHeaderName(resourceMgr tools.SourceProvider) (string, error) {
+	return "Authorization", nil
+}
+
+func (t Tool) GetParameters() parameters.Parameters {
+	return t.Parameters
+}
+
+// ReferencedTools returns the tools of the steps.
+func (t Tool) ReferencedTools() []string {
+	var names []string
+	for _, s := range t.Steps {
+		if !slices.Contains(names, s.Tool) {
+			names = append(names, s.Tool)
+		}
+	}
+	return names
+}
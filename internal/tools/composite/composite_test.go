@@ -0,0 +1,346 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/composite"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const customerOrders = `
+kind: tool
+name: customer_orders
+type: composite
+description: Lists the orders of a customer.
+parameters:
+	- name: email
+		type: string
+		description: The email of the customer.
+steps:
+	- name: customer
+		tool: find-customer
+		params:
+			email: $.params.email
+	- name: orders
+		tool: list-orders
+		when: $.steps.customer[0]
+		params:
+			customer_id: $.steps.customer[0].id
+			limit: 10
+	- name: notify
+		tool: notify
+		when: $.steps.customer[0].status == "vip"
+		params:
+			message: $$ for a vip
+result:
+	customer: $.steps.customer[0].name
+	orders: $.steps.orders
+`
+
+func TestParseFromYamlComposite(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := server.ToolConfigs{
+		"customer_orders": composite.Config{
+			Name:        "customer_orders",
+			Type:        "composite",
+			Description: "Lists the orders of a customer.",
+			Parameters: parameters.Parameters{
+				parameters.NewStringParameter("email", "The email of the customer."),
+			},
+			Steps: []composite.Step{
+				{Name: "customer", Tool: "find-customer", Params: map[string]any{"email": "$.params.email"}},
+				{Name: "orders", Tool: "list-orders", When: "$.steps.customer[0]", Params: map[string]any{"customer_id": "$.steps.customer[0].id", "limit": uint64(10)}},
+				{Name: "notify", Tool: "notify", When: `$.steps.customer[0].status == "vip"`, Params: map[string]any{"message": "$$ for a vip"}},
+			},
+			Result:       map[string]any{"customer": "$.steps.customer[0].name", "orders": "$.steps.orders"},
+			AuthRequired: []string{},
+		},
+	}
+	_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(customerOrders))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestInitializeErrors(t *testing.T) {
+	tcs := []struct {
+		desc  string
+		steps []composite.Step
+		res   any
+		want  string
+	}{
+		{
+			desc:  "duplicate step",
+			steps: []composite.Step{{Name: "a", Tool: "t"}, {Name: "a", Tool: "t"}},
+			want:  `duplicate step name "a"`,
+		},
+		{
+			desc:  "invalid step name",
+			steps: []composite.Step{{Name: "a.b", Tool: "t"}},
+			want:  `invalid step name "a.b"`,
+		},
+		{
+			desc:  "invalid param path",
+			steps: []composite.Step{{Name: "a", Tool: "t", Params: map[string]any{"p": "$.steps[0"}}},
+			want:  `invalid param "p" of step "a"`,
+		},
+		{
+			desc:  "invalid condition",
+			steps: []composite.Step{{Name: "a", Tool: "t", When: "$.params.x == vip"}},
+			want:  `invalid condition of step "a"`,
+		},
+		{
+			desc:  "invalid result",
+			steps: []composite.Step{{Name: "a", Tool: "t"}},
+			res:   []any{"$x"},
+			want:  `invalid result`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := composite.Config{Name: "c", Type: "composite", Description: "d", Steps: tc.steps, Result: tc.res}
+			_, err := cfg.Initialize(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+// fakeRunner runs the tools of the steps with canned results, and records
+// their calls.
+type fakeRunner struct {
+	results map[string]any
+	calls   []string
+}
+
+func (r *fakeRunner) run(_ context.Context, name string, args map[string]any) (any, error) {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	r.calls = append(r.calls, fmt.Sprintf("%s %s", name, b))
+	res, ok := r.results[name]
+	if !ok {
+		return nil, fmt.Errorf("tool %q failed", name)
+	}
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	return res, nil
+}
+
+func TestInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, _, cfgs, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(customerOrders))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	tool, err := cfgs["customer_orders"].Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params, err := parameters.ParseParams(tool.GetParameters(), map[string]any{"email": "ada@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+
+	tcs := []struct {
+		desc      string
+		results   map[string]any
+		want      any
+		wantCalls []string
+		wantErr   string
+	}{
+		{
+			desc: "all steps",
+			results: map[string]any{
+				"find-customer": []any{map[string]any{"id": 7, "name": "Ada", "status": "vip"}},
+				// results that are JSON strings are decoded
+				"list-orders": `[{"id": 1}, {"id": 2}]`,
+				"notify":      "sent",
+			},
+			want: map[string]any{
+				"customer": "Ada",
+				"orders":   []any{map[string]any{"id": json.Number("1")}, map[string]any{"id": json.Number("2")}},
+			},
+			wantCalls: []string{
+				`find-customer {"email":"ada@example.com"}`,
+				`list-orders {"customer_id":7,"limit":10}`,
+				`notify {"message":"$ for a vip"}`,
+			},
+		},
+		{
+			desc: "skipped steps",
+			results: map[string]any{
+				"find-customer": []any{},
+			},
+			want: map[string]any{
+				"customer": nil,
+				"orders":   nil,
+			},
+			wantCalls: []string{
+				`find-customer {"email":"ada@example.com"}`,
+			},
+		},
+		{
+			desc: "failed step",
+			results: map[string]any{
+				"find-customer": []any{map[string]any{"id": 7, "status": "regular"}},
+			},
+			wantErr: `step "orders" failed: tool "list-orders" failed`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := &fakeRunner{results: tc.results}
+			got, tbErr := tool.Invoke(tools.WithToolRunner(ctx, r.run), nil, params, "")
+			if tc.wantErr != "" {
+				if tbErr == nil || tbErr.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %q", tbErr, tc.wantErr)
+				}
+				if tbErr.Category() != util.CategoryAgent {
+					t.Fatalf("got category %q, want %q", tbErr.Category(), util.CategoryAgent)
+				}
+				return
+			}
+			if tbErr != nil {
+				t.Fatalf("unexpected error: %s", tbErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.wantCalls, r.calls); diff != "" {
+				t.Fatalf("incorrect calls: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeUnauthorizedStep(t *testing.T) {
+	cfg := composite.Config{
+		Name:        "c",
+		Type:        "composite",
+		Description: "d",
+		Steps:       []composite.Step{{Name: "a", Tool: "secret"}},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	run := func(context.Context, string, map[string]any) (any, error) {
+		return nil, util.NewClientServerError("unauthorized tool call", http.StatusUnauthorized, nil)
+	}
+	_, tbErr := tool.Invoke(tools.WithToolRunner(context.Background(), run), nil, parameters.ParamValues{}, "")
+	var csErr *util.ClientServerError
+	if tbErr == nil || !errors.As(tbErr, &csErr) || csErr.Code != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want an unauthorized error", tbErr)
+	}
+
+	// the steps can't be run without a runner
+	_, tbErr = tool.Invoke(context.Background(), nil, parameters.ParamValues{}, "")
+	if tbErr == nil || tbErr.Category() != util.CategoryServer {
+		t.Fatalf("got error %v, want a server error", tbErr)
+	}
+}
+
+func TestInvokeRecursion(t *testing.T) {
+	cfg := composite.Config{
+		Name:        "c",
+		Type:        "composite",
+		Description: "d",
+		Steps:       []composite.Step{{Name: "a", Tool: "c"}},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	var run tools.ToolRunner
+	run = func(ctx context.Context, _ string, _ map[string]any) (any, error) {
+		return tool.Invoke(tools.WithToolRunner(ctx, run), nil, parameters.ParamValues{}, "")
+	}
+	_, tbErr := tool.Invoke(tools.WithToolRunner(context.Background(), run), nil, parameters.ParamValues{}, "")
+	if tbErr == nil || !strings.Contains(tbErr.Error(), `tool "c" calls itself`) {
+		t.Fatalf("got error %v, want a recursion error", tbErr)
+	}
+}
+
+func TestReferencedTools(t *testing.T) {
+	cfg := composite.Config{
+		Name:        "c",
+		Type:        "composite",
+		Description: "d",
+		Steps:       []composite.Step{{Name: "a", Tool: "t1"}, {Name: "b", Tool: "t2"}, {Name: "c", Tool: "t1"}},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	got := tool.(tools.ToolReferrer).ReferencedTools()
+	if diff := cmp.Diff([]string{"t1", "t2"}, got); diff != "" {
+		t.Fatalf("incorrect referenced tools: diff %v", diff)
+	}
+}
+
+func TestCheckToolReferencesWrapped(t *testing.T) {
+	cfg := composite.Config{
+		Name:        "c",
+		Type:        "composite",
+		Description: "d",
+		Steps:       []composite.Step{{Name: "a", Tool: "c"}},
+	}
+	var wrapped tools.ToolConfig = tools.PolicyConfig{
+		ToolConfig: tools.ConstrainedConfig{
+			ToolConfig: tools.ConfirmationConfig{
+				ToolConfig: tools.OutputConfig{ToolConfig: cfg, Output: tools.Output{Limit: 1}},
+			},
+		},
+		Policies: tools.Policies{{Claim: "email", EndsWith: "@example.com"}},
+	}
+	tool, err := wrapped.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	err = tools.CheckToolReferences(map[string]tools.Tool{"c": tool})
+	if err == nil || !strings.Contains(err.Error(), `tool "c" calls itself: c -> c`) {
+		t.Fatalf("got error %v, want a recursion error", err)
+	}
+	err = tools.CheckToolReferences(map[string]tools.Tool{"other": tool})
+	if err == nil || !strings.Contains(err.Error(), `tool "other" references tool "c", which does not exist`) {
+		t.Fatalf("got error %v, want a missing tool error", err)
+	}
+}
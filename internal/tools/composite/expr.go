@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/jsonpath"
+)
+
+// value is a value built from the data of the invocation: a path, a literal,
+// or an object or list of values.
+type value interface {
+	eval(data any) any
+}
+
+type pathValue struct{ p jsonpath.Path }
+
+func (v pathValue) eval(data any) any { return v.p.Get(data) }
+
+type literalValue struct{ v any }
+
+func (v literalValue) eval(any) any { return v.v }
+
+type objectValue map[string]value
+
+func (v objectValue) eval(data any) any {
+	out := make(map[string]any, len(v))
+	for k, item := range v {
+		out[k] = item.eval(data)
+	}
+	return out
+}
+
+type listValue []value
+
+func (v listValue) eval(data any) any {
+	out := make([]any, len(v))
+	for i, item := range v {
+		out[i] = item.eval(data)
+	}
+	return out
+}
+
+// parseValue parses a value of the config. Strings starting with `$` are
+// paths, unless they start with `$$`, which is an escaped `$`.
+func parseValue(v any) (value, error) {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "$$") {
+			return literalValue{v[1:]}, nil
+		}
+		if strings.HasPrefix(v, "$") {
+			p, err := jsonpath.Parse(v)
+			if err != nil {
+				return nil, err
+			}
+			return pathValue{p}, nil
+		}
+		return literalValue{v}, nil
+	case map[string]any:
+		out := make(objectValue, len(v))
+		for k, item := range v {
+			parsed, err := parseValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = parsed
+		}
+		return out, nil
+	case []any:
+		out := make(listValue, len(v))
+		for i, item := range v {
+			parsed, err := parseValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = parsed
+		}
+		return out, nil
+	default:
+		// literals have the same types as the results of the steps
+		n, err := jsonpath.Normalize(v)
+		if err != nil {
+			return nil, err
+		}
+		return literalValue{n}, nil
+	}
+}
+
+// condition is the condition of a step. It is either an operand that must be
+// truthy, its negation with `!`, or the comparison of two operands with `==`
+// or `!=`. Operands are paths or JSON literals.
+type condition struct {
+	left, right value
+	op          string
+}
+
+func parseCondition(s string) (*condition, error) {
+	s = strings.TrimSpace(s)
+	for _, op := range []string{"==", "!="} {
+		left, right, ok := strings.Cut(s, op)
+		if !ok {
+			continue
+		}
+		l, err := parseOperand(left)
+		if err != nil {
+			return nil, err
+		}
+		r, err := parseOperand(right)
+		if err != nil {
+			return nil, err
+		}
+		return &condition{left: l, right: r, op: op}, nil
+	}
+	if rest, ok := strings.CutPrefix(s, "!"); ok {
+		operand, err := parseOperand(rest)
+		if err != nil {
+			return nil, err
+		}
+		return &condition{left: operand, op: "!"}, nil
+	}
+	operand, err := parseOperand(s)
+	if err != nil {
+		return nil, err
+	}
+	return &condition{left: operand}, nil
+}
+
+func parseOperand(s string) (value, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "$") {
+		p, err := jsonpath.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return pathValue{p}, nil
+	}
+	var v any
+	if err := util.DecodeJSON(strings.NewReader(s), &v); err != nil {
+		return nil, fmt.Errorf("invalid operand %q: must be a path or a JSON literal", s)
+	}
+	return literalValue{v}, nil
+}
+
+func (c *condition) eval(data any) bool {
+	left := c.left.eval(data)
+	switch c.op {
+	case "==":
+		return equal(left, c.right.eval(data))
+	case "!=":
+		return !equal(left, c.right.eval(data))
+	case "!":
+		return !truthy(left)
+	default:
+		return truthy(left)
+	}
+}
+
+// truthy reports whether v is set: not null, false, zero or empty.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case json.Number:
+		f, err := v.Float64()
+		return err != nil || f != 0
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+func equal(a, b any) bool {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		af, aerr := an.Float64()
+		bf, berr := bn.Float64()
+		if aerr == nil && berr == nil {
+			return af == bf
+		}
+		return an == bn
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var testData = map[string]any{
+	"params": map[string]any{"email": "ada@example.com", "limit": json.Number("0")},
+	"steps": map[string]any{
+		"customer": []any{
+			map[string]any{"id": json.Number("7"), "first name": "Ada", "vip": true},
+			map[string]any{"id": json.Number("8"), "first name": "Grace", "vip": false},
+		},
+	},
+}
+
+func TestCondition(t *testing.T) {
+	tcs := []struct {
+		cond string
+		want bool
+	}{
+		{cond: "$.steps.customer", want: true},
+		{cond: "$.steps.customer[2]", want: false},
+		{cond: "$.params.limit", want: false},
+		{cond: "!$.params.limit", want: true},
+		{cond: "$.steps.customer[0].vip", want: true},
+		{cond: "$.steps.customer[1].vip", want: false},
+		{cond: `$.steps.customer[0]["first name"] == "Ada"`, want: true},
+		{cond: `$.steps.customer[0]["first name"] != "Ada"`, want: false},
+		{cond: "$.steps.customer[0].id == 7.0", want: true},
+		{cond: "$.steps.customer[0].id == $.steps.customer[1].id", want: false},
+		{cond: "$.steps.missing == null", want: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.cond, func(t *testing.T) {
+			c, err := parseCondition(tc.cond)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := c.eval(testData); got != tc.want {
+				t.Fatalf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// Actions of an elicitation result
+const (
+	ElicitActionAccept  = "accept"
+	ElicitActionDecline = "decline"
+	ElicitActionCancel  = "cancel"
+)
+
+// ElicitResult is the response of the user to an elicitation request.
+type ElicitResult struct {
+	Action  string         `json:"action"`
+	Content map[string]any `json:"content,omitempty"`
+}
+
+// Elicitor requests input from the user of the MCP client, through the
+// `elicitation/create` request.
+type Elicitor interface {
+	Elicit(ctx context.Context, message string, requestedSchema map[string]any) (ElicitResult, error)
+}
+
+type elicitorKey struct{}
+
+// WithElicitor adds the elicitor of the client into the context. It is only
+// set for clients supporting elicitation.
+func WithElicitor(ctx context.Context, e Elicitor) context.Context {
+	return context.WithValue(ctx, elicitorKey{}, e)
+}
+
+// ElicitorFromContext retrieves the elicitor of the client, if the client
+// supports elicitation.
+func ElicitorFromContext(ctx context.Context) (Elicitor, bool) {
+	e, ok := ctx.Value(elicitorKey{}).(Elicitor)
+	return e, ok
+}
+
+var _ ToolConfig = ConfirmationConfig{}
+
+// ConfirmationConfig wraps the config of a tool that has
+// `requireConfirmation`, so that it is supported by every tool type.
+type ConfirmationConfig struct {
+	ToolConfig
+}
+
+// Initialize initializes the wrapped tool.
+func (c ConfirmationConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	t, err := c.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	return confirmationTool{Tool: t}, nil
+}
+
+// confirmationTool asks the user to confirm each invocation of the wrapped
+// tool.
+type confirmationTool struct {
+	Tool
+}
+
+func (t confirmationTool) Invoke(ctx context.Context, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, util.ToolboxError) {
+	name := t.McpManifest().Name
+	e, ok := ElicitorFromContext(ctx)
+	if !ok {
+		return nil, util.NewAgentError(fmt.Sprintf("tool %q requires confirmation from the user, but the client does not support elicitation", name), nil)
+	}
+	res, err := e.Elicit(ctx, t.confirmationMessage(params), map[string]any{"type": "object", "properties": map[string]any{}})
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("unable to get confirmation from the user for tool %q", name), err)
+	}
+	if res.Action != ElicitActionAccept {
+		return nil, util.NewAgentError(fmt.Sprintf("the user did not confirm the invocation of tool %q (%s)", name, res.Action), nil)
+	}
+	return t.Tool.Invoke(ctx, resourceMgr, params, accessToken)
+}
+
+// confirmationMessage summarizes the operation and its parameters.
+func (t confirmationTool) confirmationMessage(params parameters.ParamValues) string {
+	m := t.McpManifest()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Allow tool %q to run?", m.Name)
+	if desc, _, _ := strings.Cut(strings.TrimSpace(m.Description), "\n"); desc != "" {
+		fmt.Fprintf(&b, "\n\n%s", desc)
+	}
+	if len(params) > 0 {
+		b.WriteString("\n\nParameters:")
+		for _, p := range params {
+			v, err := json.Marshal(p.Value)
+			if err != nil {
+				v = []byte(fmt.Sprint(p.Value))
+			}
+			fmt.Fprintf(&b, "\n- %s: %s", p.Name, v)
+		}
+	}
+	return b.String()
+}
+
+func (t confirmationTool) ToConfig() ToolConfig {
+	return ConfirmationConfig{ToolConfig: t.Tool.ToConfig()}
+}
+
+// Unwrap returns the wrapped tool.
+func (t confirmationTool) Unwrap() Tool {
+	return t.Tool
+}
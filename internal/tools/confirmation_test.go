@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// fakeElicitor answers every elicitation request with the given action.
+type fakeElicitor struct {
+	action  string
+	message string
+}
+
+func (e *fakeElicitor) Elicit(_ context.Context, message string, _ map[string]any) (tools.ElicitResult, error) {
+	e.message = message
+	return tools.ElicitResult{Action: e.action}, nil
+}
+
+func TestRequireConfirmation(t *testing.T) {
+	params := parameters.Parameters{parameters.NewStringParameter("collection", "the collection")}
+	tool, err := tools.ConfirmationConfig{ToolConfig: fakeConfig{params: params}}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := tool.ToConfig().(tools.ConfirmationConfig); !ok {
+		t.Errorf("ToConfig should return the confirmation config")
+	}
+	values := parameters.ParamValues{{Name: "collection", Value: "orders"}}
+
+	// clients without elicitation get a tool error
+	_, tbErr := tool.Invoke(context.Background(), nil, values, "")
+	if tbErr == nil || !strings.Contains(tbErr.Error(), "client does not support elicitation") {
+		t.Fatalf("expected confirmation error, got %v", tbErr)
+	}
+
+	tcs := []struct {
+		action string
+		ok     bool
+	}{
+		{action: tools.ElicitActionAccept, ok: true},
+		{action: tools.ElicitActionDecline},
+		{action: tools.ElicitActionCancel},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.action, func(t *testing.T) {
+			e := &fakeElicitor{action: tc.action}
+			res, tbErr := tool.Invoke(tools.WithElicitor(context.Background(), e), nil, values, "")
+			if tc.ok != (tbErr == nil) {
+				t.Fatalf("unexpected result %v, error %v", res, tbErr)
+			}
+			if !strings.Contains(e.message, `Allow tool "fake" to run?`) || !strings.Contains(e.message, `- collection: "orders"`) {
+				t.Errorf("message doesn't summarize the invocation: %q", e.message)
+			}
+		})
+	}
+}
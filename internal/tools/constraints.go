@@ -0,0 +1,346 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// Constraint is a validation rule spanning several parameters of a tool. A
+// parameter counts as provided if it has a value after ParseParams, which
+// includes default values. Exactly one rule must be set.
+type Constraint struct {
+	// ExactlyOneOf requires exactly one of the parameters to be provided.
+	ExactlyOneOf []string `yaml:"exactlyOneOf"`
+	// AtLeastOneOf requires at least one of the parameters to be provided.
+	AtLeastOneOf []string `yaml:"atLeastOneOf"`
+	// DependentRequired maps a parameter to the parameters that must be
+	// provided along with it.
+	DependentRequired map[string][]string `yaml:"dependentRequired"`
+	// LessThan requires the first parameter to be less than the second one
+	// when both are provided. Strings (e.g. dates) are compared lexically.
+	LessThan []string `yaml:"lessThan"`
+	// Message replaces the default error message.
+	Message string `yaml:"message"`
+}
+
+// Constraints is the list of constraints of a tool.
+type Constraints []Constraint
+
+// validate checks that every constraint sets a single rule, references
+// parameters provided by the caller and is satisfied by the default values
+// of the parameters.
+func (cs Constraints) validate(params parameters.Parameters) error {
+	byName := make(map[string]parameters.Parameter, len(params))
+	for _, p := range params {
+		byName[p.GetName()] = p
+	}
+	for i, c := range cs {
+		var rules int
+		var referenced []string
+		if len(c.ExactlyOneOf) > 0 {
+			rules++
+			if len(c.ExactlyOneOf) < 2 {
+				return fmt.Errorf("constraint %d: exactlyOneOf requires at least 2 parameters", i)
+			}
+			referenced = append(referenced, c.ExactlyOneOf...)
+		}
+		if len(c.AtLeastOneOf) > 0 {
+			rules++
+			referenced = append(referenced, c.AtLeastOneOf...)
+		}
+		if len(c.DependentRequired) > 0 {
+			rules++
+			for k, v := range c.DependentRequired {
+				referenced = append(referenced, k)
+				referenced = append(referenced, v...)
+			}
+		}
+		if len(c.LessThan) > 0 {
+			rules++
+			if len(c.LessThan) != 2 {
+				return fmt.Errorf("constraint %d: lessThan requires exactly 2 parameters", i)
+			}
+			referenced = append(referenced, c.LessThan...)
+		}
+		if rules != 1 {
+			return fmt.Errorf("constraint %d: must specify exactly one of exactlyOneOf, atLeastOneOf, dependentRequired or lessThan", i)
+		}
+		for _, name := range referenced {
+			p, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("constraint %d: %q is not a parameter of the tool", i, name)
+			}
+			// the caller can't provide the parameters resolved by the server
+			if p.GetValueFromParam() != "" || p.GetFromHeader() != "" {
+				return fmt.Errorf("constraint %d: %q is not provided by the caller", i, name)
+			}
+		}
+		// embedded parameters are vectors by the time the tool is invoked
+		for _, name := range c.LessThan {
+			if byName[name].GetEmbeddedBy() != "" {
+				return fmt.Errorf("constraint %d: lessThan can't compare the embedded parameter %q", i, name)
+			}
+		}
+		if err := c.checkDefaults(byName); err != nil {
+			return fmt.Errorf("constraint %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// checkDefaults rejects default values violating the constraint, as they
+// count as provided on every call.
+func (c Constraint) checkDefaults(byName map[string]parameters.Parameter) error {
+	hasDefault := func(name string) bool {
+		return byName[name].GetDefault() != nil
+	}
+	switch {
+	case len(c.ExactlyOneOf) > 0:
+		var defaulted []string
+		for _, name := range c.ExactlyOneOf {
+			if hasDefault(name) {
+				defaulted = append(defaulted, name)
+			}
+		}
+		if len(defaulted) > 1 {
+			return fmt.Errorf("exactlyOneOf can't be satisfied, as %s have default values", quote(defaulted))
+		}
+	case len(c.DependentRequired) > 0:
+		for _, name := range slices.Sorted(maps.Keys(c.DependentRequired)) {
+			if !hasDefault(name) {
+				continue
+			}
+			for _, dep := range c.DependentRequired[name] {
+				if !hasDefault(dep) {
+					return fmt.Errorf("%q has a default value, so %q must have one too", name, dep)
+				}
+			}
+		}
+	case len(c.LessThan) == 2:
+		a, b := c.LessThan[0], c.LessThan[1]
+		if !hasDefault(a) || !hasDefault(b) {
+			return nil
+		}
+		less, err := lessThan(byName[a].GetDefault(), byName[b].GetDefault())
+		if err != nil {
+			return fmt.Errorf("unable to compare the default values of %q and %q: %w", a, b, err)
+		}
+		if !less {
+			return fmt.Errorf("the default value of %q must be less than the one of %q", a, b)
+		}
+	}
+	return nil
+}
+
+// Check evaluates the constraints against the parsed parameter values.
+func (cs Constraints) Check(values parameters.ParamValues) util.ToolboxError {
+	m := values.AsMap()
+	provided := func(name string) bool {
+		return m[name] != nil
+	}
+	for _, c := range cs {
+		var violated []string
+		var msg string
+		switch {
+		case len(c.ExactlyOneOf) > 0:
+			var n int
+			for _, name := range c.ExactlyOneOf {
+				if provided(name) {
+					n++
+				}
+			}
+			if n != 1 {
+				violated = c.ExactlyOneOf
+				msg = fmt.Sprintf("exactly one of %s must be provided", quote(violated))
+			}
+		case len(c.AtLeastOneOf) > 0:
+			if !slices.ContainsFunc(c.AtLeastOneOf, provided) {
+				violated = c.AtLeastOneOf
+				msg = fmt.Sprintf("at least one of %s must be provided", quote(violated))
+			}
+		case len(c.DependentRequired) > 0:
+			for _, name := range slices.Sorted(maps.Keys(c.DependentRequired)) {
+				if !provided(name) {
+					continue
+				}
+				if i := slices.IndexFunc(c.DependentRequired[name], func(s string) bool { return !provided(s) }); i >= 0 {
+					violated = []string{name, c.DependentRequired[name][i]}
+					msg = fmt.Sprintf("%q must be provided when %q is provided", violated[1], violated[0])
+					break
+				}
+			}
+		case len(c.LessThan) == 2:
+			a, b := c.LessThan[0], c.LessThan[1]
+			if !provided(a) || !provided(b) {
+				continue
+			}
+			less, err := lessThan(m[a], m[b])
+			if err != nil {
+				return util.NewAgentError(fmt.Sprintf("unable to compare %q and %q", a, b), err)
+			}
+			if !less {
+				violated = c.LessThan
+				msg = fmt.Sprintf("%q must be less than %q", a, b)
+			}
+		}
+		if violated == nil {
+			continue
+		}
+		if c.Message != "" {
+			msg = fmt.Sprintf("%s (parameters: %s)", c.Message, quote(violated))
+		}
+		return util.NewAgentError(fmt.Sprintf("invalid parameters: %s", msg), nil)
+	}
+	return nil
+}
+
+// apply reflects the constraints that JSON Schema can express into the MCP
+// input schema.
+func (cs Constraints) apply(schema *parameters.McpToolsSchema) {
+	for _, c := range cs {
+		switch {
+		case len(c.ExactlyOneOf) > 0:
+			schema.AllOf = append(schema.AllOf, parameters.McpSchemaRule{OneOf: requiredEach(c.ExactlyOneOf)})
+		case len(c.AtLeastOneOf) > 0:
+			schema.AllOf = append(schema.AllOf, parameters.McpSchemaRule{AnyOf: requiredEach(c.AtLeastOneOf)})
+		case len(c.DependentRequired) > 0:
+			if schema.DependentRequired == nil {
+				schema.DependentRequired = make(map[string][]string)
+			}
+			for k, v := range c.DependentRequired {
+				schema.DependentRequired[k] = append(schema.DependentRequired[k], v...)
+			}
+		}
+	}
+	// a single rule doesn't need to be wrapped in allOf
+	if len(schema.AllOf) == 1 {
+		schema.OneOf, schema.AnyOf = schema.AllOf[0].OneOf, schema.AllOf[0].AnyOf
+		schema.AllOf = nil
+	}
+}
+
+func requiredEach(names []string) []parameters.McpSchemaRule {
+	rules := make([]parameters.McpSchemaRule, len(names))
+	for i, name := range names {
+		rules[i] = parameters.McpSchemaRule{Required: []string{name}}
+	}
+	return rules
+}
+
+func lessThan(a, b any) (bool, error) {
+	if as, ok := a.(string); ok {
+		bs, ok := b.(string)
+		if !ok {
+			return false, fmt.Errorf("%v and %v are not of the same type", a, b)
+		}
+		return as < bs, nil
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if !aok || !bok {
+		return false, fmt.Errorf("%v and %v are not comparable", a, b)
+	}
+	return af < bf, nil
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func quote(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+var _ ToolConfig = ConstrainedConfig{}
+
+// ConstrainedConfig wraps the config of a tool that has `constraints`, so
+// that they are supported by every tool type.
+type ConstrainedConfig struct {
+	ToolConfig
+	Constraints Constraints
+}
+
+// Initialize initializes the wrapped tool and checks that the constraints
+// reference its parameters.
+func (c ConstrainedConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	t, err := c.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Constraints.validate(t.GetParameters()); err != nil {
+		return nil, fmt.Errorf("invalid constraints: %w", err)
+	}
+	return constrainedTool{Tool: t, constraints: c.Constraints}, nil
+}
+
+// constrainedTool checks the constraints before invoking the wrapped tool.
+type constrainedTool struct {
+	Tool
+	constraints Constraints
+}
+
+// EmbedParams checks the constraints against the values of the caller before
+// embedding them. The values violating them aren't embedded, so that the
+// embedding model isn't called for an invocation that Invoke rejects.
+func (t constrainedTool) EmbedParams(ctx context.Context, params parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	if t.constraints.Check(params) != nil {
+		return params, nil
+	}
+	return t.Tool.EmbedParams(ctx, params, embeddingModelsMap)
+}
+
+func (t constrainedTool) Invoke(ctx context.Context, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, util.ToolboxError) {
+	if err := t.constraints.Check(params); err != nil {
+		return nil, err
+	}
+	return t.Tool.Invoke(ctx, resourceMgr, params, accessToken)
+}
+
+func (t constrainedTool) McpManifest() McpManifest {
+	m := t.Tool.McpManifest()
+	t.constraints.apply(&m.InputSchema)
+	return m
+}
+
+func (t constrainedTool) ToConfig() ToolConfig {
+	return ConstrainedConfig{ToolConfig: t.Tool.ToConfig(), Constraints: t.constraints}
+}
+
+// Unwrap returns the wrapped tool.
+func (t constrainedTool) Unwrap() Tool {
+	return t.Tool
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// fakeConfig initializes a fakeTool with the given parameters.
+type fakeConfig struct {
+	params parameters.Parameters
+	// embedded counts the calls to EmbedParams
+	embedded *int
+}
+
+func (c fakeConfig) ToolConfigType() string { return "fake" }
+
+func (c fakeConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return fakeTool{cfg: c}, nil
+}
+
+// fakeTool implements the subset of tools.Tool used by the constraints.
+type fakeTool struct {
+	tools.Tool
+	cfg fakeConfig
+}
+
+func (t fakeTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	return "ok", nil
+}
+
+func (t fakeTool) McpManifest() tools.McpManifest {
+	return tools.GetMcpManifest("fake", "fake tool", nil, t.cfg.params, nil)
+}
+
+func (t fakeTool) ToConfig() tools.ToolConfig { return t.cfg }
+
+func (t fakeTool) GetParameters() parameters.Parameters { return t.cfg.params }
+
+func (t fakeTool) EmbedParams(_ context.Context, values parameters.ParamValues, _ map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	*t.cfg.embedded++
+	return values, nil
+}
+
+func TestConstraints(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewStringParameterWithRequired("id", "the id", false),
+		parameters.NewStringParameterWithRequired("email", "the email", false),
+		parameters.NewStringParameterWithRequired("start_date", "the start date", false),
+		parameters.NewStringParameterWithRequired("end_date", "the end date", false),
+		parameters.NewIntParameterWithRequired("limit", "the limit", false),
+	}
+	var embedded int
+	cfg := tools.ConstrainedConfig{
+		ToolConfig: fakeConfig{params: params, embedded: &embedded},
+		Constraints: tools.Constraints{
+			{ExactlyOneOf: []string{"id", "email"}},
+			{DependentRequired: map[string][]string{"start_date": {"end_date"}}},
+			{LessThan: []string{"start_date", "end_date"}, Message: "start_date must be before end_date"},
+		},
+	}
+	tool, err := cfg.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		err  string
+	}{
+		{desc: "valid", in: map[string]any{"id": "1", "start_date": "2024-01-01", "end_date": "2024-02-01"}},
+		{desc: "none of exactlyOneOf", in: map[string]any{}, err: `exactly one of "id", "email" must be provided`},
+		{desc: "both of exactlyOneOf", in: map[string]any{"id": "1", "email": "a@b.c"}, err: `exactly one of "id", "email" must be provided`},
+		{desc: "dependent missing", in: map[string]any{"id": "1", "start_date": "2024-01-01"}, err: `"end_date" must be provided when "start_date" is provided`},
+		{desc: "custom message", in: map[string]any{"id": "1", "start_date": "2024-03-01", "end_date": "2024-02-01"}, err: `start_date must be before end_date (parameters: "start_date", "end_date")`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			values, err := parameters.ParseParams(params, tc.in, nil)
+			if err != nil {
+				t.Fatalf("unexpected error parsing params: %s", err)
+			}
+			embedded = 0
+			values, err = tool.EmbedParams(context.Background(), values, nil)
+			if err != nil {
+				t.Fatalf("unexpected error embedding params: %s", err)
+			}
+			_, toolErr := tool.Invoke(context.Background(), nil, values, "")
+			if tc.err == "" {
+				if toolErr != nil {
+					t.Fatalf("unexpected error: %s", toolErr)
+				}
+				if embedded != 1 {
+					t.Fatalf("expected the params to be embedded once, got %d", embedded)
+				}
+				return
+			}
+			// invocations violating the constraints aren't embedded
+			if embedded != 0 {
+				t.Fatalf("expected the params not to be embedded, got %d", embedded)
+			}
+			if toolErr == nil || !strings.Contains(toolErr.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, toolErr)
+			}
+			if toolErr.Category() != util.CategoryAgent {
+				t.Fatalf("expected an agent error, got %v", toolErr.Category())
+			}
+		})
+	}
+
+	schema := tool.McpManifest().InputSchema
+	wantOneOf := []parameters.McpSchemaRule{{Required: []string{"id"}}, {Required: []string{"email"}}}
+	if diff := cmp.Diff(wantOneOf, schema.OneOf); diff != "" {
+		t.Errorf("incorrect oneOf: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string][]string{"start_date": {"end_date"}}, schema.DependentRequired); diff != "" {
+		t.Errorf("incorrect dependentRequired: diff %v", diff)
+	}
+	if _, ok := tool.ToConfig().(tools.ConstrainedConfig); !ok {
+		t.Errorf("ToConfig should return the constrained config")
+	}
+}
+
+func TestInvalidConstraints(t *testing.T) {
+	fromHeader := parameters.NewStringParameter("tenant", "the tenant")
+	fromHeader.FromHeader = "X-Tenant"
+	hidden := parameters.NewStringParameter("id_copy", "the id")
+	hidden.ValueFromParam = "id"
+	embedded := parameters.NewStringParameter("query", "the query")
+	embedded.EmbeddedBy = "my-model"
+	params := parameters.Parameters{
+		parameters.NewStringParameter("id", "the id"),
+		parameters.NewStringParameter("email", "the email"),
+		fromHeader,
+		hidden,
+		embedded,
+		parameters.NewStringParameterWithDefault("region", "us", "the region"),
+		parameters.NewStringParameterWithDefault("zone", "eu-1", "the zone"),
+		parameters.NewIntParameterWithDefault("min", 10, "the min"),
+		parameters.NewIntParameterWithDefault("max", 5, "the max"),
+	}
+	tcs := []struct {
+		desc       string
+		constraint tools.Constraint
+		err        string
+	}{
+		{desc: "unknown parameter", constraint: tools.Constraint{AtLeastOneOf: []string{"id", "name"}}, err: `"name" is not a parameter of the tool`},
+		{desc: "no rule", constraint: tools.Constraint{Message: "oops"}, err: "must specify exactly one of"},
+		{desc: "multiple rules", constraint: tools.Constraint{AtLeastOneOf: []string{"id"}, ExactlyOneOf: []string{"id", "email"}}, err: "must specify exactly one of"},
+		{desc: "lessThan arity", constraint: tools.Constraint{LessThan: []string{"id"}}, err: "lessThan requires exactly 2 parameters"},
+		{desc: "header parameter", constraint: tools.Constraint{AtLeastOneOf: []string{"id", "tenant"}}, err: `"tenant" is not provided by the caller`},
+		{desc: "hidden parameter", constraint: tools.Constraint{ExactlyOneOf: []string{"id", "id_copy"}}, err: `"id_copy" is not provided by the caller`},
+		{desc: "lessThan embedded parameter", constraint: tools.Constraint{LessThan: []string{"query", "id"}}, err: `lessThan can't compare the embedded parameter "query"`},
+		{desc: "exactlyOneOf defaults", constraint: tools.Constraint{ExactlyOneOf: []string{"region", "zone"}}, err: "exactlyOneOf can't be satisfied"},
+		{desc: "dependentRequired default", constraint: tools.Constraint{DependentRequired: map[string][]string{"region": {"id"}}}, err: `"region" has a default value, so "id" must have one too`},
+		{desc: "lessThan defaults", constraint: tools.Constraint{LessThan: []string{"min", "max"}}, err: `the default value of "min" must be less than the one of "max"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tools.ConstrainedConfig{ToolConfig: fakeConfig{params: params}, Constraints: tools.Constraints{tc.constraint}}
+			_, err := cfg.Initialize(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// IngestContentParam is the name of the parameter holding the text of the
+// documents saved by ingestion tools.
+const IngestContentParam = "content"
+
+// ingestChunksParam is the name of the value holding the embedded chunks of
+// the content, added by EmbedChunks.
+const ingestChunksParam = "_chunks"
+
+// IngestSource is implemented by sources that can write the chunks of a
+// document in a single transaction.
+type IngestSource interface {
+	RunSQLBatch(ctx context.Context, statement string, batch [][]any) (any, error)
+}
+
+// Chunking configures how ingestion tools split long texts. Consecutive
+// chunks share Overlap characters, so that sentences cut at the boundary of a
+// chunk are still embedded together.
+type Chunking struct {
+	// Size is the maximum number of characters of a chunk.
+	Size int `yaml:"size"`
+	// Overlap is the number of characters shared by consecutive chunks.
+	Overlap int `yaml:"overlap"`
+}
+
+// Validate checks that the chunks make progress through the text.
+func (c Chunking) Validate() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("chunking size must be positive")
+	}
+	if c.Overlap < 0 || c.Overlap >= c.Size {
+		return fmt.Errorf("chunking overlap must be between 0 and the size of a chunk")
+	}
+	return nil
+}
+
+// Split splits the text into chunks of at most Size characters. Chunks end at
+// a whitespace when one is found in their second half.
+func (c Chunking) Split(text string) []string {
+	runes := []rune(text)
+	if len(runes) <= c.Size {
+		return []string{text}
+	}
+	var chunks []string
+	for start := 0; start < len(runes); {
+		end := min(start+c.Size, len(runes))
+		if end < len(runes) {
+			for i := end; i > start+c.Size/2; i-- {
+				if unicode.IsSpace(runes[i]) {
+					end = i
+					break
+				}
+			}
+		}
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			break
+		}
+		start = max(end-c.Overlap, start+1)
+	}
+	return chunks
+}
+
+// Chunk is a part of the content saved by an ingestion tool.
+type Chunk struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+	// Embedding is formatted for the database, and left out of the
+	// confirmation message of the tool
+	Embedding any `json:"-"`
+}
+
+// EmbedChunks splits the content parameter with chunking, if set, and embeds
+// the chunks with the model. The chunks are added to the parameter values, to
+// be retrieved by the tool with ChunksFromParams.
+func EmbedChunks(ctx context.Context, paramValues parameters.ParamValues, chunking *Chunking, model string, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, formatter embeddingmodels.VectorFormatter) (parameters.ParamValues, error) {
+	m, ok := embeddingModelsMap[model]
+	if !ok {
+		return nil, fmt.Errorf("embedding model does not exist: %s", model)
+	}
+	content, ok := paramValues.AsMap()[IngestContentParam].(string)
+	if !ok {
+		return nil, fmt.Errorf("parameter %q is missing or not a string", IngestContentParam)
+	}
+	texts := []string{content}
+	if chunking != nil {
+		texts = chunking.Split(content)
+	}
+
+	embeddings, err := m.EmbedParameters(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("error embedding content with model %s: %w", model, err)
+	}
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("model %s returned %d embeddings for %d inputs", model, len(embeddings), len(texts))
+	}
+	chunks := make([]Chunk, len(texts))
+	for i, text := range texts {
+		var embedding any = embeddings[i]
+		if formatter != nil {
+			embedding = formatter(embeddings[i])
+		}
+		chunks[i] = Chunk{Index: i, Text: text, Embedding: embedding}
+	}
+	return append(paramValues, parameters.ParamValue{Name: ingestChunksParam, Value: chunks}), nil
+}
+
+// ChunksFromParams retrieves the chunks added by EmbedChunks.
+func ChunksFromParams(paramValues parameters.ParamValues) ([]Chunk, error) {
+	chunks, ok := paramValues.AsMap()[ingestChunksParam].([]Chunk)
+	if !ok {
+		return nil, fmt.Errorf("the content was not embedded")
+	}
+	return chunks, nil
+}
+
+// IngestRows returns the statement params of each chunk: its text, its
+// embedding and its index, followed by the metadata.
+func IngestRows(chunks []Chunk, metadata []any) [][]any {
+	rows := make([][]any, len(chunks))
+	for i, c := range chunks {
+		rows[i] = append([]any{c.Text, c.Embedding, c.Index}, metadata...)
+	}
+	return rows
+}
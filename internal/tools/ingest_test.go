@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestChunkingSplit(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		chunking tools.Chunking
+		in       string
+		want     []string
+	}{
+		{
+			desc:     "short text",
+			chunking: tools.Chunking{Size: 100},
+			in:       "a short note",
+			want:     []string{"a short note"},
+		},
+		{
+			desc:     "breaks at whitespace",
+			chunking: tools.Chunking{Size: 10},
+			in:       "the quick brown fox jumps",
+			want:     []string{"the quick", " brown fox", " jumps"},
+		},
+		{
+			desc:     "no whitespace",
+			chunking: tools.Chunking{Size: 4, Overlap: 1},
+			in:       "abcdefghij",
+			want:     []string{"abcd", "defg", "ghij"},
+		},
+		{
+			desc:     "multibyte characters",
+			chunking: tools.Chunking{Size: 3},
+			in:       "héllo wörld",
+			want:     []string{"hél", "lo", " wö", "rld"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.chunking.Split(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect chunks: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestIngestRows(t *testing.T) {
+	chunks := []tools.Chunk{
+		{Index: 0, Text: "first", Embedding: "[1]"},
+		{Index: 1, Text: "second", Embedding: "[2]"},
+	}
+	got := tools.IngestRows(chunks, []any{"title", 42})
+	want := [][]any{
+		{"first", "[1]", 0, "title", 42},
+		{"second", "[2]", 1, "title", 42},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect rows: diff %v", diff)
+	}
+}
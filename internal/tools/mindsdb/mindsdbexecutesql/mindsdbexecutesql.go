@@ -55,6 +55,8 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly only runs read-only statements, such as SELECT.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -73,6 +75,9 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Description: cfg.Description,
 		InputSchema: inputSchema,
 	}
+	if cfg.ReadOnly {
+		mcpManifest.Annotations = tools.NewReadOnlyAnnotations()
+	}
 
 	t := Tool{
 		Config:      cfg,
@@ -108,6 +113,9 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, util.NewAgentError(fmt.Sprintf("unable to get cast %s", paramsMap["sql"]), nil)
 	}
 
+	if err := tools.CheckReadOnlyStatement(t.ReadOnly, "mindsdb", sqlStr); err != nil {
+		return nil, err
+	}
 	resp, err := source.RunSQL(ctx, sqlStr, nil)
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
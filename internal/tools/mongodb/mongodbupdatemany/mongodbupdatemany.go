@@ -47,6 +47,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	MongoClient() *mongo.Client
 	UpdateMany(context.Context, string, bool, string, string, string, bool) ([]any, error)
+	DryRunUpdateMany(context.Context, string, bool, string, string, string, bool) (any, error)
 }
 
 type Config struct {
@@ -74,8 +75,15 @@ func (cfg Config) ToolConfigType() string {
 }
 
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	dryRunParameter := parameters.NewBooleanParameterWithDefault(
+		"dry_run",
+		false,
+		"If set to true, the number of documents matching the filter is returned without updating them. "+
+			"Defaults to false.",
+	)
+
 	// Create a slice for all parameters
-	allParameters := slices.Concat(cfg.FilterParams, cfg.UpdateParams)
+	allParameters := slices.Concat(cfg.FilterParams, cfg.UpdateParams, parameters.Parameters{dryRunParameter})
 
 	// Verify no duplicate parameter names
 	err := parameters.CheckDuplicateParameters(allParameters)
@@ -128,6 +136,13 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if err != nil {
 		return nil, util.NewAgentError("unable to get update", err)
 	}
+	if dryRun, _ := paramsMap["dry_run"].(bool); dryRun {
+		resp, err := source.DryRunUpdateMany(ctx, filterString, t.Canonical, updateString, t.Database, t.Collection, t.Upsert)
+		if err != nil {
+			return nil, util.ProcessGeneralError(err)
+		}
+		return resp, nil
+	}
 	resp, err := source.UpdateMany(ctx, filterString, t.Canonical, updateString, t.Database, t.Collection, t.Upsert)
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
@@ -136,7 +151,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
-	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, nil)
+	return parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsFloatList)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -55,6 +55,8 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly only runs read-only statements, such as SELECT.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 // validate interface
@@ -65,10 +67,17 @@ func (cfg Config) ToolConfigType() string {
 }
 
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	if err := sources.CheckArbitraryStatements(srcs[cfg.Source]); err != nil {
+		return nil, fmt.Errorf("invalid source %q for %q tool: %w", cfg.Source, resourceType, err)
+	}
 	sqlParameter := parameters.NewStringParameter("sql", "The sql to execute.")
 	params := parameters.Parameters{sqlParameter}
 
-	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+	var annotations *tools.ToolAnnotations
+	if cfg.ReadOnly {
+		annotations = tools.NewReadOnlyAnnotations()
+	}
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, annotations)
 
 	// finish tool setup
 	t := Tool{
@@ -108,6 +117,9 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, util.NewClientServerError("error getting logger", http.StatusInternalServerError, err)
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("executing `%s` tool query: %s", resourceType, sqlStr))
+	if err := tools.CheckReadOnlyStatement(t.ReadOnly, "mssql", sqlStr); err != nil {
+		return nil, err
+	}
 	resp, err := source.RunSQL(ctx, sqlStr, nil)
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
@@ -55,6 +55,27 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read only",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mssql-execute-sql
+            source: my-instance
+            description: some description
+            readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mssqlexecutesql.Config{
+					Name:         "example_tool",
+					Type:         "mssql-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					ReadOnly:     true,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -23,6 +23,7 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -49,12 +50,21 @@ type compatibleSource interface {
 	RunSQL(context.Context, string, []any) (any, error)
 }
 
+// callerSource is implemented by sources that resolve the connection of the
+// caller for dry runs and read-only statements.
+type callerSource interface {
+	DryRunSQL(context.Context, string, []any) (any, error)
+	ReadOnlySQL(context.Context, string, []any) (any, error)
+}
+
 type Config struct {
 	Name         string   `yaml:"name" validate:"required"`
 	Type         string   `yaml:"type" validate:"required"`
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly only runs read-only statements, in read-only transactions.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 // validate interface
@@ -66,9 +76,21 @@ func (cfg Config) ToolConfigType() string {
 
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	sqlParameter := parameters.NewStringParameter("sql", "The sql to execute.")
+	dryRunParameter := parameters.NewBooleanParameterWithDefault(
+		"dry_run",
+		false,
+		"If set to true, the statement is executed in a transaction that is rolled back, and the number of "+
+			"rows it affects is returned instead of its results. Defaults to false.",
+	)
 	params := parameters.Parameters{sqlParameter}
+	var annotations *tools.ToolAnnotations
+	if cfg.ReadOnly {
+		annotations = tools.NewReadOnlyAnnotations()
+	} else {
+		params = append(params, dryRunParameter)
+	}
 
-	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, annotations)
 
 	// finish tool setup
 	t := Tool{
@@ -101,6 +123,8 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if !ok {
 		return nil, util.NewAgentError(fmt.Sprintf("unable to get cast %s", paramsMap["sql"]), nil)
 	}
+	// dry_run is not a parameter of read-only tools
+	dryRun, _ := paramsMap["dry_run"].(bool)
 
 	// Log the query executed for debugging.
 	logger, err := util.LoggerFromContext(ctx)
@@ -108,7 +132,20 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		return nil, util.NewClientServerError("error getting logger", http.StatusInternalServerError, err)
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("executing `%s` tool query: %s", resourceType, sqlStr))
-	resp, err := source.RunSQL(ctx, sqlStr, nil)
+	var resp any
+	cs, isCallerSource := source.(callerSource)
+	switch {
+	case t.ReadOnly && isCallerSource:
+		resp, err = cs.ReadOnlySQL(ctx, sqlStr, nil)
+	case t.ReadOnly:
+		resp, err = mysql.ReadOnlySQL(ctx, source.MySQLPool(), sqlStr, nil)
+	case dryRun && isCallerSource:
+		resp, err = cs.DryRunSQL(ctx, sqlStr, nil)
+	case dryRun:
+		resp, err = mysql.DryRunSQL(ctx, source.MySQLPool(), sqlStr, nil)
+	default:
+		resp, err = source.RunSQL(ctx, sqlStr, nil)
+	}
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
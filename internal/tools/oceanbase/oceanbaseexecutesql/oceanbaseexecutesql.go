@@ -47,6 +47,8 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly only runs read-only statements, such as SELECT.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 // validate interface
@@ -68,7 +70,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	sqlParameter := parameters.NewStringParameter("sql", "The sql to execute.")
 	params := parameters.Parameters{sqlParameter}
 
-	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+	var annotations *tools.ToolAnnotations
+	if cfg.ReadOnly {
+		annotations = tools.NewReadOnlyAnnotations()
+	}
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, annotations)
 
 	// finish tool setup
 	t := Tool{
@@ -102,6 +108,9 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if !ok {
 		return nil, util.NewAgentError(fmt.Sprintf("unable to get cast %s", sliceParams[0]), nil)
 	}
+	if err := tools.CheckReadOnlyStatement(t.ReadOnly, "oceanbase", sqlStr); err != nil {
+		return nil, err
+	}
 	resp, err := source.RunSQL(ctx, sqlStr, nil)
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
@@ -56,6 +56,27 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read only",
+			in: `
+            kind: tool
+            name: example_tool
+            type: oceanbase-execute-sql
+            source: my-instance
+            description: some description
+            readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": oceanbaseexecutesql.Config{
+					Name:         "example_tool",
+					Type:         "oceanbase-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					ReadOnly:     true,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,308 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/jsonpath"
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// Formats of the result of a tool
+const (
+	OutputFormatJSON     = "json"
+	OutputFormatCSV      = "csv"
+	OutputFormatMarkdown = "markdown"
+)
+
+// Output reshapes the result of a tool before it is returned. The transforms
+// are applied in the order of the fields. The field transforms apply to each
+// row of a list, or to the result itself if it is an object.
+type Output struct {
+	// Path selects the part of the result to return, such as `$.items`.
+	Path string `yaml:"path"`
+	// Flatten replaces the nested objects of the rows with fields named after
+	// their path, such as `address.city`.
+	Flatten bool `yaml:"flatten"`
+	// Select keeps only these fields, in this order.
+	Select []string `yaml:"select"`
+	// Drop removes these fields.
+	Drop []string `yaml:"drop"`
+	// Rename maps the name of fields to their new name.
+	Rename map[string]string `yaml:"rename"`
+	// Limit keeps at most this number of rows.
+	Limit int `yaml:"limit" validate:"gte=0"`
+	// Format is the format of the result: json (default), csv or markdown.
+	Format string `yaml:"format" validate:"omitempty,oneof=json csv markdown"`
+}
+
+// FormattedResult is a result formatted as text, such as a CSV or Markdown
+// table. It is returned to the client as is, rather than as a JSON string.
+type FormattedResult string
+
+// Validate checks the path of the output.
+func (o Output) Validate() error {
+	if o.Path != "" {
+		if _, err := jsonpath.Parse(o.Path); err != nil {
+			return err
+		}
+	}
+	if len(o.Select) > 0 && len(o.Drop) > 0 {
+		return fmt.Errorf("select and drop can't be used together")
+	}
+	return nil
+}
+
+// Apply reshapes the result of a tool.
+func (o Output) Apply(res any) (any, error) {
+	v, err := jsonpath.NormalizeOrdered(res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the result: %w", err)
+	}
+	if o.Path != "" {
+		p, err := jsonpath.Parse(o.Path)
+		if err != nil {
+			return nil, err
+		}
+		v = p.Get(v)
+	}
+
+	v = mapRows(v, o.transformRow)
+	if list, ok := v.([]any); ok && o.Limit > 0 && len(list) > o.Limit {
+		v = list[:o.Limit]
+	}
+
+	switch o.Format {
+	case OutputFormatCSV:
+		return formatCSV(v)
+	case OutputFormatMarkdown:
+		return formatMarkdown(v), nil
+	default:
+		return v, nil
+	}
+}
+
+// mapRows applies f to each row of a list, or to v if it is a row.
+func mapRows(v any, f func(orderedmap.Row) orderedmap.Row) any {
+	switch v := v.(type) {
+	case orderedmap.Row:
+		return f(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			if row, ok := item.(orderedmap.Row); ok {
+				out[i] = f(row)
+			} else {
+				out[i] = item
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (o Output) transformRow(row orderedmap.Row) orderedmap.Row {
+	if o.Flatten {
+		row = flatten(row, "")
+	}
+	if len(o.Select) > 0 {
+		var selected orderedmap.Row
+		for _, name := range o.Select {
+			if i := slices.IndexFunc(row.Columns, func(c orderedmap.Column) bool { return c.Name == name }); i >= 0 {
+				selected.Add(name, row.Columns[i].Value)
+			}
+		}
+		row = selected
+	}
+	if len(o.Drop) > 0 || len(o.Rename) > 0 {
+		var out orderedmap.Row
+		for _, col := range row.Columns {
+			if slices.Contains(o.Drop, col.Name) {
+				continue
+			}
+			name := col.Name
+			if renamed, ok := o.Rename[name]; ok {
+				name = renamed
+			}
+			out.Add(name, col.Value)
+		}
+		row = out
+	}
+	return row
+}
+
+// flatten replaces the nested rows of row with their columns, prefixed by the
+// path of the nested row.
+func flatten(row orderedmap.Row, prefix string) orderedmap.Row {
+	var out orderedmap.Row
+	for _, col := range row.Columns {
+		name := prefix + col.Name
+		if nested, ok := col.Value.(orderedmap.Row); ok {
+			out.Columns = append(out.Columns, flatten(nested, name+".").Columns...)
+			continue
+		}
+		out.Add(name, col.Value)
+	}
+	return out
+}
+
+// table returns the header and the cells of the rows of v. Values that aren't
+// rows are in a "value" column.
+func table(v any) ([]string, [][]string) {
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+	var header []string
+	for _, item := range items {
+		row, ok := item.(orderedmap.Row)
+		if !ok {
+			row = orderedmap.Row{Columns: []orderedmap.Column{{Name: "value", Value: item}}}
+		}
+		for _, col := range row.Columns {
+			if !slices.Contains(header, col.Name) {
+				header = append(header, col.Name)
+			}
+		}
+	}
+	cells := make([][]string, len(items))
+	for i, item := range items {
+		row, ok := item.(orderedmap.Row)
+		if !ok {
+			row = orderedmap.Row{Columns: []orderedmap.Column{{Name: "value", Value: item}}}
+		}
+		cells[i] = make([]string, len(header))
+		for _, col := range row.Columns {
+			cells[i][slices.Index(header, col.Name)] = cell(col.Value)
+		}
+	}
+	return header, cells
+}
+
+// cell formats a value of a table. Nested values are formatted as JSON.
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+func formatCSV(v any) (FormattedResult, error) {
+	header, cells := table(v)
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	if err := w.WriteAll(cells); err != nil {
+		return "", err
+	}
+	return FormattedResult(buf.String()), nil
+}
+
+func formatMarkdown(v any) FormattedResult {
+	header, cells := table(v)
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	var b strings.Builder
+	writeRow := func(values []string) {
+		b.WriteString("|")
+		for _, value := range values {
+			fmt.Fprintf(&b, " %s |", escape.Replace(value))
+		}
+		b.WriteString("\n")
+	}
+	writeRow(header)
+	b.WriteString("|")
+	for range header {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range cells {
+		writeRow(row)
+	}
+	return FormattedResult(b.String())
+}
+
+var _ ToolConfig = OutputConfig{}
+
+// OutputConfig wraps the config of a tool that has an `output`, so that it is
+// supported by every tool type.
+type OutputConfig struct {
+	ToolConfig
+	Output Output
+}
+
+// Initialize initializes the wrapped tool and validates the output.
+func (c OutputConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	if err := c.Output.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+	t, err := c.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	return outputTool{Tool: t, output: c.Output}, nil
+}
+
+// outputTool reshapes the results of the wrapped tool.
+type outputTool struct {
+	Tool
+	output Output
+}
+
+func (t outputTool) Invoke(ctx context.Context, resourceMgr SourceProvider, params parameters.ParamValues, accessToken AccessToken) (any, util.ToolboxError) {
+	res, tbErr := t.Tool.Invoke(ctx, resourceMgr, params, accessToken)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	out, err := t.output.Apply(res)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to transform the result of the tool", http.StatusInternalServerError, err)
+	}
+	return out, nil
+}
+
+func (t outputTool) ToConfig() ToolConfig {
+	return OutputConfig{ToolConfig: t.Tool.ToConfig(), Output: t.output}
+}
+
+// Unwrap returns the wrapped tool.
+func (t outputTool) Unwrap() Tool {
+	return t.Tool
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+)
+
+func row(cols ...any) orderedmap.Row {
+	var r orderedmap.Row
+	for i := 0; i < len(cols); i += 2 {
+		r.Add(cols[i].(string), cols[i+1])
+	}
+	return r
+}
+
+func TestOutputApply(t *testing.T) {
+	// rows as returned by the SQL tools
+	rows := []any{
+		row("id", 1, "name", "Ada", "ssn", "123", "address", map[string]any{"city": "London"}),
+		row("id", 2, "name", "Grace|Hopper", "ssn", "456", "address", map[string]any{"city": "New York"}),
+		row("id", 3, "name", "Alan", "ssn", "789", "address", nil),
+	}
+
+	tcs := []struct {
+		desc   string
+		output tools.Output
+		in     any
+		want   any
+	}{
+		{
+			desc:   "select and rename",
+			output: tools.Output{Select: []string{"name", "id"}, Rename: map[string]string{"name": "customer"}},
+			in:     rows[:1],
+			want:   []any{row("customer", "Ada", "id", json.Number("1"))},
+		},
+		{
+			desc:   "drop and limit",
+			output: tools.Output{Drop: []string{"ssn", "address"}, Limit: 2},
+			in:     rows,
+			want:   []any{row("id", json.Number("1"), "name", "Ada"), row("id", json.Number("2"), "name", "Grace|Hopper")},
+		},
+		{
+			desc:   "path and flatten",
+			output: tools.Output{Path: "$.data.users", Flatten: true},
+			in:     `{"data": {"users": [{"id": 1, "profile": {"name": "Ada", "links": {"home": "ada.dev"}}}]}}`,
+			want:   []any{row("id", json.Number("1"), "profile.name", "Ada", "profile.links.home", "ada.dev")},
+		},
+		{
+			desc:   "path to a single object",
+			output: tools.Output{Path: "$[0]", Select: []string{"name"}},
+			in:     rows,
+			want:   row("name", "Ada"),
+		},
+		{
+			desc:   "missing path",
+			output: tools.Output{Path: "$.missing"},
+			in:     rows,
+			want:   nil,
+		},
+		{
+			desc:   "csv",
+			output: tools.Output{Flatten: true, Drop: []string{"ssn"}, Format: tools.OutputFormatCSV},
+			in:     rows,
+			want:   tools.FormattedResult("id,name,address.city,address\n1,Ada,London,\n2,Grace|Hopper,New York,\n3,Alan,,\n"),
+		},
+		{
+			desc:   "markdown",
+			output: tools.Output{Select: []string{"name", "address"}, Format: tools.OutputFormatMarkdown},
+			in:     rows,
+			want: tools.FormattedResult("| name | address |\n| --- | --- |\n" +
+				"| Ada | {\"city\":\"London\"} |\n" +
+				"| Grace\\|Hopper | {\"city\":\"New York\"} |\n" +
+				"| Alan |  |\n"),
+		},
+		{
+			desc:   "markdown of values",
+			output: tools.Output{Format: tools.OutputFormatMarkdown},
+			in:     []any{"a\nb", 2},
+			want:   tools.FormattedResult("| value |\n| --- |\n| a<br>b |\n| 2 |\n"),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.output.Apply(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestOutputValidate(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		output tools.Output
+	}{
+		{desc: "invalid path", output: tools.Output{Path: "items"}},
+		{desc: "select and drop", output: tools.Output{Select: []string{"a"}, Drop: []string{"b"}}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.output.Validate(); err == nil {
+				t.Fatal("expected an error")
+			}
+			if _, err := (tools.OutputConfig{ToolConfig: fakeConfig{}, Output: tc.output}).Initialize(nil); err == nil {
+				t.Fatal("expected an initialization error")
+			}
+		})
+	}
+}
+
+func TestOutputTool(t *testing.T) {
+	output := tools.Output{Format: tools.OutputFormatCSV}
+	tool, err := tools.OutputConfig{ToolConfig: fakeConfig{}, Output: output}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(tools.OutputConfig{ToolConfig: fakeConfig{}, Output: output}, tool.ToConfig(), cmp.AllowUnexported(fakeConfig{})); diff != "" {
+		t.Errorf("incorrect config: diff %v", diff)
+	}
+	got, tbErr := tool.Invoke(context.Background(), nil, nil, "")
+	if tbErr != nil {
+		t.Fatalf("unexpected error: %s", tbErr)
+	}
+	if got != tools.FormattedResult("value\nok\n") {
+		t.Fatalf("got %q, want the csv of the result", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// Policy is an authorization rule evaluated against the claims of the
+// verified auth services. Exactly one matcher must be set.
+type Policy struct {
+	// AuthService restricts the policy to the claims of a single auth
+	// service. If empty, the claims of any verified auth service can satisfy
+	// the policy.
+	AuthService string `yaml:"authService"`
+	// Claim is the name of the claim the policy is evaluated against.
+	Claim string `yaml:"claim" validate:"required"`
+	// Equals requires the claim to be equal to the value.
+	Equals string `yaml:"equals"`
+	// OneOf requires the claim to be equal to one of the values.
+	OneOf []string `yaml:"oneOf"`
+	// Contains requires a list claim (e.g. `groups`) to contain the value. A
+	// string claim is split on whitespace, as for the `scope` claim.
+	Contains string `yaml:"contains"`
+	// EndsWith requires the claim to end with the value.
+	EndsWith string `yaml:"endsWith"`
+}
+
+// Policies is a list of policies that must all be satisfied.
+type Policies []Policy
+
+// Validate checks that every policy sets a single matcher.
+func (ps Policies) Validate() error {
+	for i, p := range ps {
+		if p.Claim == "" {
+			return fmt.Errorf("policy %d: claim is required", i)
+		}
+		var matchers int
+		for _, set := range []bool{p.Equals != "", len(p.OneOf) > 0, p.Contains != "", p.EndsWith != ""} {
+			if set {
+				matchers++
+			}
+		}
+		if matchers != 1 {
+			return fmt.Errorf("policy %d: must specify exactly one of equals, oneOf, contains or endsWith", i)
+		}
+	}
+	return nil
+}
+
+// Check evaluates the policies against the claims of the verified auth
+// services, keyed by auth service name. It returns a 403 ClientServerError
+// for the first policy that isn't satisfied.
+func (ps Policies) Check(claimsFromAuth map[string]map[string]any) util.ToolboxError {
+	for _, p := range ps {
+		satisfied := false
+		for name, claims := range claimsFromAuth {
+			if p.AuthService != "" && p.AuthService != name {
+				continue
+			}
+			if p.matches(claims[p.Claim]) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return util.NewClientServerError(fmt.Sprintf("forbidden: claim %q does not satisfy the authorization policy", p.Claim), http.StatusForbidden, nil)
+		}
+	}
+	return nil
+}
+
+func (p Policy) matches(v any) bool {
+	if v == nil {
+		return false
+	}
+	switch {
+	case p.Equals != "":
+		return fmt.Sprintf("%v", v) == p.Equals
+	case len(p.OneOf) > 0:
+		return slices.Contains(p.OneOf, fmt.Sprintf("%v", v))
+	case p.EndsWith != "":
+		s, ok := v.(string)
+		return ok && strings.HasSuffix(s, p.EndsWith)
+	case p.Contains != "":
+		switch c := v.(type) {
+		case string:
+			return slices.Contains(strings.Fields(c), p.Contains)
+		case []any:
+			return slices.ContainsFunc(c, func(e any) bool { return fmt.Sprintf("%v", e) == p.Contains })
+		case []string:
+			return slices.Contains(c, p.Contains)
+		}
+	}
+	return false
+}
+
+// CheckPolicies evaluates the policies of the tool, if any, against the
+// claims of the verified auth services. They include the policies of the
+// toolsets containing the tool.
+func CheckPolicies(t Tool, claimsFromAuth map[string]map[string]any) util.ToolboxError {
+	if pt, ok := t.(policyTool); ok {
+		if err := pt.policies.Check(claimsFromAuth); err != nil {
+			return err
+		}
+		return pt.toolsetPolicies.Check(claimsFromAuth)
+	}
+	return nil
+}
+
+// ApplyToolsetPolicies adds the policies of every toolset to its tools. The
+// policies are then enforced however the tool is reached, e.g. through the
+// default toolset or `/api/tool/{name}/invoke`, not only through the
+// toolset. It must be called before the toolsets are initialized.
+func ApplyToolsetPolicies(toolsMap map[string]Tool, toolsetConfigs map[string]ToolsetConfig) {
+	names := make([]string, 0, len(toolsetConfigs))
+	for name := range toolsetConfigs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		tc := toolsetConfigs[name]
+		if len(tc.Policies) == 0 {
+			continue
+		}
+		for _, toolName := range tc.ToolNames {
+			t, ok := toolsMap[toolName]
+			if !ok {
+				continue
+			}
+			pt, ok := t.(policyTool)
+			if !ok {
+				pt = policyTool{Tool: t}
+			}
+			pt.toolsetPolicies = append(slices.Clone(pt.toolsetPolicies), tc.Policies...)
+			toolsMap[toolName] = pt
+		}
+	}
+}
+
+var _ ToolConfig = PolicyConfig{}
+
+// PolicyConfig wraps the config of a tool that has `policies`, so that they
+// are supported by every tool type. It must be the outermost wrapper so that
+// CheckPolicies can find the policies of the tool.
+type PolicyConfig struct {
+	ToolConfig
+	Policies Policies
+}
+
+// Initialize initializes the wrapped tool and validates the policies.
+func (c PolicyConfig) Initialize(srcs map[string]sources.Source) (Tool, error) {
+	if err := c.Policies.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policies: %w", err)
+	}
+	t, err := c.ToolConfig.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	return policyTool{Tool: t, policies: c.Policies}, nil
+}
+
+// policyTool carries the policies of the wrapped tool, which are checked by
+// the server before the tool is listed or invoked.
+type policyTool struct {
+	Tool
+	policies Policies
+	// toolsetPolicies are the policies of the toolsets containing the tool
+	toolsetPolicies Policies
+}
+
+func (t policyTool) ToConfig() ToolConfig {
+	if len(t.policies) == 0 {
+		return t.Tool.ToConfig()
+	}
+	return PolicyConfig{ToolConfig: t.Tool.ToConfig(), Policies: t.policies}
+}
+
+// Unwrap returns the wrapped tool.
+func (t policyTool) Unwrap() Tool {
+	return t.Tool
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestPolicies(t *testing.T) {
+	claims := map[string]map[string]any{
+		"my-auth": {
+			"email":  "jane@example.com",
+			"groups": []any{"data-eng", "analysts"},
+			"scope":  "openid db.read db.write",
+			"role":   "admin",
+		},
+	}
+	tcs := []struct {
+		desc   string
+		policy tools.Policy
+		want   bool
+	}{
+		{desc: "equals", policy: tools.Policy{Claim: "role", Equals: "admin"}, want: true},
+		{desc: "equals mismatch", policy: tools.Policy{Claim: "role", Equals: "viewer"}},
+		{desc: "oneOf", policy: tools.Policy{Claim: "role", OneOf: []string{"viewer", "admin"}}, want: true},
+		{desc: "contains list", policy: tools.Policy{Claim: "groups", Contains: "data-eng"}, want: true},
+		{desc: "contains list mismatch", policy: tools.Policy{Claim: "groups", Contains: "finance"}},
+		{desc: "contains scope", policy: tools.Policy{Claim: "scope", Contains: "db.write"}, want: true},
+		{desc: "contains scope substring", policy: tools.Policy{Claim: "scope", Contains: "db"}},
+		{desc: "endsWith", policy: tools.Policy{Claim: "email", EndsWith: "@example.com"}, want: true},
+		{desc: "missing claim", policy: tools.Policy{Claim: "hd", Equals: "example.com"}},
+		{desc: "auth service", policy: tools.Policy{AuthService: "my-auth", Claim: "role", Equals: "admin"}, want: true},
+		{desc: "other auth service", policy: tools.Policy{AuthService: "other-auth", Claim: "role", Equals: "admin"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.Policies{tc.policy}.Check(claims)
+			if tc.want {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var csErr *util.ClientServerError
+			if !errors.As(err, &csErr) || csErr.Code != http.StatusForbidden {
+				t.Fatalf("expected a 403 error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidPolicies(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		policy tools.Policy
+		err    string
+	}{
+		{desc: "no claim", policy: tools.Policy{Equals: "admin"}, err: "claim is required"},
+		{desc: "no matcher", policy: tools.Policy{Claim: "role"}, err: "must specify exactly one of"},
+		{desc: "multiple matchers", policy: tools.Policy{Claim: "role", Equals: "admin", EndsWith: "min"}, err: "must specify exactly one of"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tools.PolicyConfig{ToolConfig: fakeConfig{}, Policies: tools.Policies{tc.policy}}
+			_, err := cfg.Initialize(nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestToolsetAuthorize(t *testing.T) {
+	open, err := fakeConfig{}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	restricted, err := tools.PolicyConfig{
+		ToolConfig: fakeConfig{},
+		Policies:   tools.Policies{{Claim: "groups", Contains: "data-eng"}},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := restricted.ToConfig().(tools.PolicyConfig); !ok {
+		t.Errorf("ToConfig should return the policy config")
+	}
+
+	toolset := tools.Toolset{
+		ToolsetConfig: tools.ToolsetConfig{
+			Name:     "my-toolset",
+			Policies: tools.Policies{{Claim: "email", EndsWith: "@example.com"}},
+		},
+		Tools:       []*tools.Tool{&open, &restricted},
+		McpManifest: []tools.McpManifest{{Name: "open"}, {Name: "restricted"}},
+		Manifest: tools.ToolsetManifest{
+			ToolsManifest: map[string]tools.Manifest{"open": {}, "restricted": {}},
+		},
+	}
+
+	tcs := []struct {
+		desc   string
+		claims map[string]map[string]any
+		want   []string
+		err    bool
+	}{
+		{desc: "all tools", claims: map[string]map[string]any{"my-auth": {"email": "a@example.com", "groups": []any{"data-eng"}}}, want: []string{"open", "restricted"}},
+		{desc: "filtered", claims: map[string]map[string]any{"my-auth": {"email": "a@example.com"}}, want: []string{"open"}},
+		{desc: "toolset forbidden", claims: map[string]map[string]any{"my-auth": {"email": "a@other.com"}}, err: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := toolset.Authorize(tc.claims)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(got.McpManifest) != len(tc.want) || len(got.Tools) != len(tc.want) || len(got.Manifest.ToolsManifest) != len(tc.want) {
+				t.Fatalf("got %d tools, want %v", len(got.McpManifest), tc.want)
+			}
+			for i, name := range tc.want {
+				if got.McpManifest[i].Name != name {
+					t.Errorf("tool %d = %q, want %q", i, got.McpManifest[i].Name, name)
+				}
+				if _, ok := got.Manifest.ToolsManifest[name]; !ok {
+					t.Errorf("manifest is missing tool %q", name)
+				}
+			}
+		})
+	}
+	if err := tools.CheckPolicies(open, nil); err != nil {
+		t.Errorf("tools without policies should be authorized, got %s", err)
+	}
+}
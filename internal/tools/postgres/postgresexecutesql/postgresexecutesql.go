@@ -22,6 +22,7 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
@@ -49,12 +50,21 @@ type compatibleSource interface {
 	RunSQL(context.Context, string, []any) (any, error)
 }
 
+// callerSource is implemented by sources that resolve the connection of the
+// caller for dry runs and read-only statements.
+type callerSource interface {
+	DryRunSQL(context.Context, string, []any) (any, error)
+	ReadOnlySQL(context.Context, string, []any) (any, error)
+}
+
 type Config struct {
 	Name         string   `yaml:"name" validate:"required"`
 	Type         string   `yaml:"type" validate:"required"`
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly runs statements in read-only transactions.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -64,10 +74,25 @@ func (cfg Config) ToolConfigType() string {
 }
 
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	if err := sources.CheckArbitraryStatements(srcs[cfg.Source]); err != nil {
+		return nil, fmt.Errorf("invalid source %q for %q tool: %w", cfg.Source, resourceType, err)
+	}
 	sqlParameter := parameters.NewStringParameter("sql", "The sql to execute.")
+	dryRunParameter := parameters.NewBooleanParameterWithDefault(
+		"dry_run",
+		false,
+		"If set to true, the statement is executed in a transaction that is rolled back, and the number of "+
+			"rows it affects is returned instead of its results. Defaults to false.",
+	)
 	params := parameters.Parameters{sqlParameter}
+	var annotations *tools.ToolAnnotations
+	if cfg.ReadOnly {
+		annotations = tools.NewReadOnlyAnnotations()
+	} else {
+		params = append(params, dryRunParameter)
+	}
 
-	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, annotations)
 
 	t := Tool{
 		Config:      cfg,
@@ -98,13 +123,28 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if !ok {
 		return nil, util.NewAgentError(fmt.Sprintf("unable to get cast %s", paramsMap["sql"]), nil)
 	}
+	// dry_run is not a parameter of read-only tools
+	dryRun, _ := paramsMap["dry_run"].(bool)
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		return nil, util.NewClientServerError("error getting logger", http.StatusInternalServerError, err)
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("executing `%s` tool query: %s", resourceType, sql))
 
-	resp, err := source.RunSQL(ctx, sql, nil)
+	var resp any
+	cs, isCallerSource := source.(callerSource)
+	switch {
+	case t.ReadOnly && isCallerSource:
+		resp, err = cs.ReadOnlySQL(ctx, sql, nil)
+	case t.ReadOnly:
+		resp, err = postgres.ReadOnlySQL(ctx, source.PostgresPool(), "", sql, nil)
+	case dryRun && isCallerSource:
+		resp, err = cs.DryRunSQL(ctx, sql, nil)
+	case dryRun:
+		resp, err = postgres.DryRunSQL(ctx, source.PostgresPool(), "", sql, nil)
+	default:
+		resp, err = source.RunSQL(ctx, sql, nil)
+	}
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresingest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const resourceType string = "postgres-ingest"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+type Config struct {
+	Name           string                 `yaml:"name" validate:"required"`
+	Type           string                 `yaml:"type" validate:"required"`
+	Source         string                 `yaml:"source" validate:"required"`
+	Description    string                 `yaml:"description" validate:"required"`
+	Statement      string                 `yaml:"statement" validate:"required"`
+	EmbeddingModel string                 `yaml:"embeddingModel" validate:"required"`
+	Chunking       *tools.Chunking        `yaml:"chunking"`
+	AuthRequired   []string               `yaml:"authRequired"`
+	Parameters     parameters.Parameters  `yaml:"parameters"`
+	Annotations    *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	if cfg.Chunking != nil {
+		if err := cfg.Chunking.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid chunking of tool %q: %w", cfg.Name, err)
+		}
+	}
+	for _, p := range cfg.Parameters {
+		if p.GetName() == tools.IngestContentParam {
+			return nil, fmt.Errorf("parameter %q of tool %q is reserved for the content to save", tools.IngestContentParam, cfg.Name)
+		}
+	}
+
+	contentParameter := parameters.NewStringParameter(tools.IngestContentParam, "The text to save. It is embedded so that it can be found by semantic search.")
+	allParameters := append(parameters.Parameters{contentParameter}, cfg.Parameters...)
+	paramManifest := allParameters.Manifest()
+	annotations := tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations)
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters, annotations)
+
+	t := Tool{
+		Config:      cfg,
+		AllParams:   allParameters,
+		manifest:    tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest: mcpManifest,
+	}
+	return t, nil
+}
+
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Config
+	AllParams   parameters.Parameters `yaml:"allParams"`
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Source, t.Name, t.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	chunks, err := tools.ChunksFromParams(params)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to retrieve the embedded content", http.StatusInternalServerError, err)
+	}
+	metadata, err := parameters.GetParams(t.Parameters, params.AsMap())
+	if err != nil {
+		return nil, util.NewAgentError("unable to extract standard params", err)
+	}
+	rows := tools.IngestRows(chunks, metadata.AsSlice())
+
+	var resp any
+	is, ok := source.(tools.IngestSource)
+	switch {
+	case ok:
+		resp, err = is.RunSQLBatch(ctx, t.Statement, rows)
+	case sources.ConnectsAsCaller(source):
+		// the shared pool would write with the credentials of the source
+		return nil, util.NewClientServerError("source can't ingest with the credentials of the caller", http.StatusInternalServerError, nil)
+	default:
+		resp, err = postgres.RunSQLBatch(ctx, source.PostgresPool(), "", t.Statement, rows)
+	}
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	out := map[string]any{"chunks": len(chunks)}
+	if r, ok := resp.([]any); ok && len(r) > 0 {
+		out["rows"] = r
+	}
+	return out, nil
+}
+
+func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	paramValues, err := parameters.EmbedParams(ctx, t.AllParams, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorAsText)
+	if err != nil {
+		return nil, err
+	}
+	return tools.EmbedChunks(ctx, paramValues, t.Chunking, t.EmbeddingModel, embeddingModelsMap, embeddingmodels.FormatVectorAsText)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
+
+func (t Tool) RequiresClientAuthorization(resourceMgr tools.SourceProvider) (bool, error) {
+	return false, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Config
+}
+
+func (t Tool) GetAuthTokenHeaderName(resourceMgr tools.SourceProvider) (string, error) {
+	return "Authorization", nil
+}
+
+func (t Tool) GetParameters() parameters.Parameters {
+	return t.AllParams
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresingest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresingest"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestParseFromYamlPostgresIngest(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: tool
+            name: save_note
+            type: postgres-ingest
+            source: my-pg-instance
+            description: Save a note.
+            embeddingModel: gemini-model
+            statement: |
+                INSERT INTO notes (content, embedding, chunk) VALUES ($1, $2, $3);
+			`,
+			want: server.ToolConfigs{
+				"save_note": postgresingest.Config{
+					Name:           "save_note",
+					Type:           "postgres-ingest",
+					Source:         "my-pg-instance",
+					Description:    "Save a note.",
+					EmbeddingModel: "gemini-model",
+					Statement:      "INSERT INTO notes (content, embedding, chunk) VALUES ($1, $2, $3);\n",
+					AuthRequired:   []string{},
+				},
+			},
+		},
+		{
+			desc: "with chunking and metadata",
+			in: `
+            kind: tool
+            name: save_document
+            type: postgres-ingest
+            source: my-pg-instance
+            description: Save a document.
+            embeddingModel: gemini-model
+            chunking:
+                size: 1000
+                overlap: 100
+            statement: |
+                INSERT INTO documents (content, embedding, chunk, title) VALUES ($1, $2, $3, $4);
+            parameters:
+                - name: title
+                  type: string
+                  description: The title of the document.
+			`,
+			want: server.ToolConfigs{
+				"save_document": postgresingest.Config{
+					Name:           "save_document",
+					Type:           "postgres-ingest",
+					Source:         "my-pg-instance",
+					Description:    "Save a document.",
+					EmbeddingModel: "gemini-model",
+					Chunking:       &tools.Chunking{Size: 1000, Overlap: 100},
+					Statement:      "INSERT INTO documents (content, embedding, chunk, title) VALUES ($1, $2, $3, $4);\n",
+					AuthRequired:   []string{},
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("title", "The title of the document."),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailInitializePostgresIngest(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  postgresingest.Config
+		err  string
+	}{
+		{
+			desc: "overlap larger than size",
+			cfg:  postgresingest.Config{Name: "t", Chunking: &tools.Chunking{Size: 10, Overlap: 10}},
+			err:  `invalid chunking of tool "t": chunking overlap must be between 0 and the size of a chunk`,
+		},
+		{
+			desc: "reserved parameter",
+			cfg: postgresingest.Config{Name: "t", Parameters: parameters.Parameters{
+				parameters.NewStringParameter("content", "some description"),
+			}},
+			err: `parameter "content" of tool "t" is reserved for the content to save`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(nil)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+type fakeModel struct{}
+
+func (fakeModel) EmbeddingModelType() string                     { return "fake" }
+func (fakeModel) ToConfig() embeddingmodels.EmbeddingModelConfig { return nil }
+func (fakeModel) EmbedParameters(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = []float32{float32(len(text)), 0.5}
+	}
+	return out, nil
+}
+
+func TestEmbedParams(t *testing.T) {
+	tool, err := postgresingest.Config{
+		Name:           "save_document",
+		EmbeddingModel: "my-model",
+		Chunking:       &tools.Chunking{Size: 10, Overlap: 3},
+		Parameters: parameters.Parameters{
+			parameters.NewStringParameter("title", "The title of the document."),
+		},
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params := parameters.ParamValues{
+		{Name: "content", Value: "hello world, goodbye"},
+		{Name: "title", Value: "greetings"},
+	}
+	got, err := tool.EmbedParams(context.Background(), params, map[string]embeddingmodels.EmbeddingModel{"my-model": fakeModel{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	chunks, err := tools.ChunksFromParams(got)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []tools.Chunk{
+		{Index: 0, Text: "hello worl", Embedding: "[10, 0.5]"},
+		{Index: 1, Text: "orld, good", Embedding: "[10, 0.5]"},
+		{Index: 2, Text: "oodbye", Embedding: "[6, 0.5]"},
+	}
+	if diff := cmp.Diff(want, chunks); diff != "" {
+		t.Fatalf("incorrect chunks: diff %v", diff)
+	}
+}
+
+// callerSource is a source connecting as the caller, without RunSQLBatch.
+type callerSource struct{}
+
+func (callerSource) SourceType() string                      { return "fake" }
+func (callerSource) ToConfig() sources.SourceConfig          { return nil }
+func (callerSource) PostgresPool() *pgxpool.Pool             { return nil }
+func (callerSource) ConnectsAsCaller() bool                  { return true }
+func (callerSource) GetSource(string) (sources.Source, bool) { return callerSource{}, true }
+
+func TestInvokeRejectsSharedPoolOfCallerSource(t *testing.T) {
+	tool, err := postgresingest.Config{
+		Name:           "save_document",
+		Source:         "my-source",
+		EmbeddingModel: "my-model",
+		Statement:      "INSERT INTO docs (content, embedding) VALUES ($1, $2)",
+	}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params, err := tool.EmbedParams(context.Background(), parameters.ParamValues{{Name: "content", Value: "hello"}}, map[string]embeddingmodels.EmbeddingModel{"my-model": fakeModel{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, toolErr := tool.Invoke(context.Background(), callerSource{}, params, "")
+	if toolErr == nil || !strings.Contains(toolErr.Error(), "credentials of the caller") {
+		t.Fatalf("expected the shared pool to be rejected, got %v", toolErr)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ToolRunner runs the tool with the given name and arguments on behalf of the
+// caller of the current request, with the same authorization checks as a
+// direct call of the tool, and returns its result.
+type ToolRunner func(ctx context.Context, name string, args map[string]any) (any, error)
+
+type toolRunnerKey struct{}
+
+// WithToolRunner adds the runner of the tools of the current request into the
+// context, for the tools calling other tools.
+func WithToolRunner(ctx context.Context, r ToolRunner) context.Context {
+	return context.WithValue(ctx, toolRunnerKey{}, r)
+}
+
+// ToolRunnerFromContext retrieves the runner of the tools of the current
+// request.
+func ToolRunnerFromContext(ctx context.Context) (ToolRunner, bool) {
+	r, ok := ctx.Value(toolRunnerKey{}).(ToolRunner)
+	return r, ok && r != nil
+}
+
+type toolsetKey struct{}
+
+// WithToolset adds the toolset the current request is served from into the
+// context, so that the tools run on behalf of the caller are checked against
+// the policies of that toolset.
+func WithToolset(ctx context.Context, ts Toolset) context.Context {
+	return context.WithValue(ctx, toolsetKey{}, ts)
+}
+
+// ToolsetFromContext retrieves the toolset the current request is served
+// from, if any.
+func ToolsetFromContext(ctx context.Context) (Toolset, bool) {
+	ts, ok := ctx.Value(toolsetKey{}).(Toolset)
+	return ts, ok
+}
+
+// ToolReferrer is implemented by the tools calling other tools, so that the
+// tools they reference are checked when the config is loaded.
+type ToolReferrer interface {
+	ReferencedTools() []string
+}
+
+// wrapperTool is implemented by the tools wrapping another tool, such as the
+// tools with `policies` or `output`.
+type wrapperTool interface {
+	Unwrap() Tool
+}
+
+// asToolReferrer returns the ToolReferrer of the tool, looking through the
+// tools it is wrapped by.
+func asToolReferrer(t Tool) (ToolReferrer, bool) {
+	for {
+		if r, ok := t.(ToolReferrer); ok {
+			return r, true
+		}
+		w, ok := t.(wrapperTool)
+		if !ok {
+			return nil, false
+		}
+		t = w.Unwrap()
+	}
+}
+
+// CheckToolReferences checks that the tools referenced by other tools exist,
+// and that no tool ends up calling itself.
+func CheckToolReferences(toolsMap map[string]Tool) error {
+	refs := make(map[string][]string)
+	for name, t := range toolsMap {
+		r, ok := asToolReferrer(t)
+		if !ok {
+			continue
+		}
+		for _, ref := range r.ReferencedTools() {
+			if _, ok := toolsMap[ref]; !ok {
+				return fmt.Errorf("tool %q references tool %q, which does not exist", name, ref)
+			}
+		}
+		refs[name] = r.ReferencedTools()
+	}
+
+	// depth-first search of the references, keeping the current path to
+	// report cycles
+	done := make(map[string]bool)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if i := slices.Index(path, name); i >= 0 {
+			return fmt.Errorf("tool %q calls itself: %s", name, strings.Join(append(path[i:], name), " -> "))
+		}
+		if done[name] {
+			return nil
+		}
+		path = append(path, name)
+		for _, ref := range refs[name] {
+			if err := visit(ref, path); err != nil {
+				return err
+			}
+		}
+		done[name] = true
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(refs)) {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// referrerTool is a tool calling other tools.
+type referrerTool struct {
+	tools.Tool
+	refs []string
+}
+
+func (t referrerTool) ReferencedTools() []string {
+	return t.refs
+}
+
+func TestCheckToolReferences(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		tools   map[string]tools.Tool
+		wantErr string
+	}{
+		{
+			desc: "valid references",
+			tools: map[string]tools.Tool{
+				"a": referrerTool{refs: []string{"b", "c"}},
+				"b": referrerTool{refs: []string{"c"}},
+				"c": referrerTool{},
+			},
+		},
+		{
+			desc: "missing tool",
+			tools: map[string]tools.Tool{
+				"a": referrerTool{refs: []string{"b"}},
+			},
+			wantErr: `tool "a" references tool "b", which does not exist`,
+		},
+		{
+			desc: "self reference",
+			tools: map[string]tools.Tool{
+				"a": referrerTool{refs: []string{"a"}},
+			},
+			wantErr: `tool "a" calls itself: a -> a`,
+		},
+		{
+			desc: "cycle",
+			tools: map[string]tools.Tool{
+				"a": referrerTool{refs: []string{"b"}},
+				"b": referrerTool{refs: []string{"c"}},
+				"c": referrerTool{refs: []string{"a"}},
+			},
+			wantErr: `tool "a" calls itself: a -> b -> c -> a`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.CheckToolReferences(tc.tools)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestToolRunnerFromContext(t *testing.T) {
+	if _, ok := tools.ToolRunnerFromContext(context.Background()); ok {
+		t.Fatal("expected no runner in an empty context")
+	}
+	runner := func(context.Context, string, map[string]any) (any, error) { return "ok", nil }
+	r, ok := tools.ToolRunnerFromContext(tools.WithToolRunner(context.Background(), runner))
+	if !ok {
+		t.Fatal("expected a runner in the context")
+	}
+	if got, _ := r(context.Background(), "t", nil); got != "ok" {
+		t.Fatalf("got %v, want %q", got, "ok")
+	}
+}
@@ -47,6 +47,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	SQLiteDB() *sql.DB
 	RunSQL(context.Context, string, []any) (any, error)
+	ReadOnlySQL(context.Context, string, []any) (any, error)
 }
 
 type Config struct {
@@ -55,6 +56,8 @@ type Config struct {
 	Source       string   `yaml:"source" validate:"required"`
 	Description  string   `yaml:"description" validate:"required"`
 	AuthRequired []string `yaml:"authRequired"`
+	// ReadOnly runs statements with `query_only` enabled.
+	ReadOnly bool `yaml:"readOnly"`
 }
 
 // validate interface
@@ -67,7 +70,11 @@ func (cfg Config) ToolConfigType() string {
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	sqlParameter := parameters.NewStringParameter("sql", "The sql to execute.")
 	params := parameters.Parameters{sqlParameter}
-	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, nil)
+	var annotations *tools.ToolAnnotations
+	if cfg.ReadOnly {
+		annotations = tools.NewReadOnlyAnnotations()
+	}
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, params, annotations)
 
 	// finish tool setup
 	t := Tool{
@@ -110,7 +117,12 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("executing `%s` tool query: %s", resourceType, sqlStr))
 
-	resp, err := source.RunSQL(ctx, sqlStr, nil)
+	var resp any
+	if t.ReadOnly {
+		resp, err = source.ReadOnlySQL(ctx, sqlStr, nil)
+	} else {
+		resp, err = source.RunSQL(ctx, sqlStr, nil)
+	}
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
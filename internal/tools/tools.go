@@ -17,6 +17,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
@@ -49,6 +50,11 @@ func Register(resourceType string, factory ToolConfigFactory) bool {
 	return true
 }
 
+// RegisteredTypes returns the sorted list of registered tool types.
+func RegisteredTypes() []string {
+	return slices.Sorted(maps.Keys(toolRegistry))
+}
+
 // DecodeConfig looks up the registered factory for the given type and uses it
 // to decode the tool configuration.
 func DecodeConfig(ctx context.Context, resourceType string, name string, decoder *yaml.Decoder) (ToolConfig, error) {
@@ -174,6 +180,15 @@ func GetMcpManifest(name, desc string, authInvoke []string, params parameters.Pa
 	return mcpManifest
 }
 
+// CheckReadOnlyStatement returns an agent error if the tool is read-only and
+// the SQL statement of the dialect doesn't only read data.
+func CheckReadOnlyStatement(readOnly bool, dialect, statement string) util.ToolboxError {
+	if !readOnly || sources.IsReadOnlyStatement(dialect, statement) {
+		return nil
+	}
+	return util.NewAgentError("the tool is read-only, only a single read-only statement such as SELECT can be executed", nil)
+}
+
 // Helper function that returns if a tool invocation request is authorized
 func IsAuthorized(authRequiredSources []string, verifiedAuthServices []string) bool {
 	if len(authRequiredSources) == 0 {
@@ -17,11 +17,15 @@ package tools
 import (
 	"fmt"
 	"regexp"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 type ToolsetConfig struct {
 	Name      string   `yaml:"name"`
 	ToolNames []string `yaml:",inline"`
+	// Policies must be satisfied by the caller to use the toolset.
+	Policies Policies `yaml:"policies"`
 }
 
 type Toolset struct {
@@ -48,6 +52,10 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	if !IsValidName(toolset.Name) {
 		return toolset, fmt.Errorf("invalid toolset name: %s", toolset.Name)
 	}
+	toolset.Policies = t.Policies
+	if err := toolset.Policies.Validate(); err != nil {
+		return toolset, fmt.Errorf("invalid policies for toolset %q: %w", toolset.Name, err)
+	}
 	toolset.Tools = make([]*Tool, 0, len(t.ToolNames))
 	toolset.Manifest = ToolsetManifest{
 		ServerVersion: serverVersion,
@@ -66,6 +74,32 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	return toolset, nil
 }
 
+// Authorize checks the policies of the toolset against the claims of the
+// verified auth services, and returns the toolset with only the tools whose
+// policies are satisfied.
+func (t Toolset) Authorize(claimsFromAuth map[string]map[string]any) (Toolset, util.ToolboxError) {
+	if err := t.Policies.Check(claimsFromAuth); err != nil {
+		return Toolset{}, err
+	}
+	authorized := t
+	authorized.Tools = make([]*Tool, 0, len(t.Tools))
+	authorized.McpManifest = make([]McpManifest, 0, len(t.McpManifest))
+	authorized.Manifest = ToolsetManifest{
+		ServerVersion: t.Manifest.ServerVersion,
+		ToolsManifest: make(map[string]Manifest),
+	}
+	for i, tool := range t.Tools {
+		if CheckPolicies(*tool, claimsFromAuth) != nil {
+			continue
+		}
+		name := t.McpManifest[i].Name
+		authorized.Tools = append(authorized.Tools, tool)
+		authorized.McpManifest = append(authorized.McpManifest, t.McpManifest[i])
+		authorized.Manifest.ToolsManifest[name] = t.Manifest.ToolsManifest[name]
+	}
+	return authorized, nil
+}
+
 var validName = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)
 
 func IsValidName(s string) bool {
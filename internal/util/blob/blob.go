@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blob provides access to configuration files stored outside of the
+// local filesystem. Backends are registered per URL scheme, so object stores
+// can be plugged in without changes to the config loading code.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrNotModified is returned by Store.Get when the blob still matches the
+// version identifier provided by the caller.
+var ErrNotModified = errors.New("blob not modified")
+
+// Object is the content of a blob along with its version identifier.
+type Object struct {
+	Data []byte
+	// ETag identifies the version of the blob. It is passed back to Get to
+	// detect changes.
+	ETag string
+}
+
+// Store is the interface for a blob backend.
+type Store interface {
+	// Get fetches the blob at uri. If etag is non-empty and the blob has not
+	// changed since that version, Get returns ErrNotModified.
+	Get(ctx context.Context, uri string, etag string) (Object, error)
+	// List returns the URIs of all YAML blobs directly under the uri prefix.
+	List(ctx context.Context, uri string) ([]string, error)
+}
+
+var (
+	mu            sync.RWMutex
+	storeRegistry = make(map[string]Store)
+)
+
+// Register registers a Store for the given URL scheme.
+// It returns false if the scheme is already registered.
+func Register(scheme string, store Store) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	scheme = strings.ToLower(scheme)
+	if _, exists := storeRegistry[scheme]; exists {
+		// Store with this scheme already exists, do not overwrite.
+		return false
+	}
+	storeRegistry[scheme] = store
+	return true
+}
+
+// IsRemote reports whether the location is handled by a registered Store
+// rather than the local filesystem.
+func IsRemote(location string) bool {
+	_, err := storeFor(location)
+	return err == nil
+}
+
+// Get fetches the blob at uri using the Store registered for its scheme.
+func Get(ctx context.Context, uri string, etag string) (Object, error) {
+	s, err := storeFor(uri)
+	if err != nil {
+		return Object{}, err
+	}
+	return s.Get(ctx, uri, etag)
+}
+
+// List lists the blobs under uri using the Store registered for its scheme.
+func List(ctx context.Context, uri string) ([]string, error) {
+	s, err := storeFor(uri)
+	if err != nil {
+		return nil, err
+	}
+	return s.List(ctx, uri)
+}
+
+func storeFor(location string) (Store, error) {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme == "" {
+		return nil, fmt.Errorf("%q is not a remote location", location)
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	s, ok := storeRegistry[strings.ToLower(u.Scheme)]
+	if !ok {
+		return nil, fmt.Errorf("no blob store registered for scheme %q", u.Scheme)
+	}
+	return s, nil
+}
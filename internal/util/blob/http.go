@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxBlobSize limits the size of a configuration file fetched over HTTP.
+const maxBlobSize = 10 << 20
+
+func init() {
+	s := NewHTTPStore(nil)
+	for _, scheme := range []string{"http", "https"} {
+		if !Register(scheme, s) {
+			panic(fmt.Sprintf("blob store for scheme %q already registered", scheme))
+		}
+	}
+}
+
+var _ Store = &HTTPStore{}
+
+// HTTPStore fetches configuration files from HTTP(S) URLs. Changes are
+// detected with conditional requests using the ETag (or Last-Modified) header
+// returned by the server. If the server returns neither, a hash of the content
+// is used instead.
+type HTTPStore struct {
+	client *http.Client
+}
+
+// NewHTTPStore returns a HTTPStore using the provided client. If client is
+// nil, a client with a default timeout is used.
+func NewHTTPStore(client *http.Client) *HTTPStore {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &HTTPStore{client: client}
+}
+
+// Get fetches the file at uri.
+func (s *HTTPStore) Get(ctx context.Context, uri string, etag string) (Object, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return Object{}, fmt.Errorf("unable to create request for %q: %w", uri, err)
+	}
+	if etag != "" {
+		if lastModified, ok := strings.CutPrefix(etag, lastModifiedPrefix); ok {
+			req.Header.Set("If-Modified-Since", lastModified)
+		} else if !strings.HasPrefix(etag, hashPrefix) {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Object{}, fmt.Errorf("unable to fetch %q: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return Object{ETag: etag}, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Object{}, fmt.Errorf("unable to fetch %q: unexpected status %d", uri, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBlobSize+1))
+	if err != nil {
+		return Object{}, fmt.Errorf("unable to read %q: %w", uri, err)
+	}
+	if len(data) > maxBlobSize {
+		return Object{}, fmt.Errorf("%q exceeds the maximum size of %d bytes", uri, maxBlobSize)
+	}
+
+	version := resp.Header.Get("ETag")
+	if version == "" && resp.Header.Get("Last-Modified") != "" {
+		version = lastModifiedPrefix + resp.Header.Get("Last-Modified")
+	}
+	if version == "" {
+		sum := sha256.Sum256(data)
+		version = hashPrefix + hex.EncodeToString(sum[:])
+	}
+	if version == etag {
+		return Object{Data: data, ETag: etag}, ErrNotModified
+	}
+	return Object{Data: data, ETag: version}, nil
+}
+
+// List is not supported, as HTTP has no standard way to list a directory.
+func (s *HTTPStore) List(ctx context.Context, uri string) ([]string, error) {
+	return nil, fmt.Errorf("listing is not supported for %q, use --configs to provide each file instead", uri)
+}
+
+// version prefixes used when the server does not return an ETag
+const (
+	lastModifiedPrefix = "last-modified:"
+	hashPrefix         = "sha256:"
+)
@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/util/blob"
+)
+
+func TestIsRemote(t *testing.T) {
+	tcs := []struct {
+		location string
+		want     bool
+	}{
+		{location: "tools.yaml", want: false},
+		{location: "configs/tools.yaml", want: false},
+		{location: "/etc/toolbox/tools.yaml", want: false},
+		{location: `C:\toolbox\tools.yaml`, want: false},
+		{location: "unknown://bucket/tools.yaml", want: false},
+		{location: "http://example.com/tools.yaml", want: true},
+		{location: "HTTPS://example.com/tools.yaml", want: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.location, func(t *testing.T) {
+			if got := blob.IsRemote(tc.location); got != tc.want {
+				t.Fatalf("IsRemote(%q) = %v, want %v", tc.location, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHTTPStoreGet(t *testing.T) {
+	content := "kind: toolset\nname: my-toolset\ntools: []\n"
+	tcs := []struct {
+		desc    string
+		headers map[string]string
+	}{
+		{desc: "etag", headers: map[string]string{"ETag": `"v1"`}},
+		{desc: "last-modified", headers: map[string]string{"Last-Modified": "Wed, 21 Oct 2015 07:28:00 GMT"}},
+		{desc: "no version headers"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				for k, v := range tc.headers {
+					w.Header().Set(k, v)
+				}
+				if inm := r.Header.Get("If-None-Match"); inm != "" && inm == tc.headers["ETag"] {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				if ims := r.Header.Get("If-Modified-Since"); ims != "" && ims == tc.headers["Last-Modified"] {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				_, _ = w.Write([]byte(content))
+			}))
+			defer srv.Close()
+
+			ctx := context.Background()
+			uri := srv.URL + "/tools.yaml"
+			obj, err := blob.Get(ctx, uri, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(obj.Data) != content {
+				t.Fatalf("got %q, want %q", obj.Data, content)
+			}
+			if obj.ETag == "" {
+				t.Fatalf("expected a version identifier")
+			}
+
+			_, err = blob.Get(ctx, uri, obj.ETag)
+			if !errors.Is(err, blob.ErrNotModified) {
+				t.Fatalf("expected ErrNotModified, got %v", err)
+			}
+			if requests != 2 {
+				t.Fatalf("expected 2 requests, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestHTTPStoreErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	ctx := context.Background()
+	if _, err := blob.Get(ctx, srv.URL+"/missing.yaml", ""); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+	if _, err := blob.List(ctx, srv.URL+"/configs/"); err == nil {
+		t.Fatalf("expected error listing over HTTP")
+	}
+	if _, err := blob.Get(ctx, "tools.yaml", ""); err == nil {
+		t.Fatalf("expected error for local path")
+	}
+}
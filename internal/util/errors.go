@@ -66,6 +66,10 @@ type ClientServerError struct {
 	Msg   string
 	Code  int
 	Cause error
+	// Caller is set for 401/403 errors caused by the credentials of the
+	// caller, such as a missing or rejected token, which are returned to the
+	// caller as is.
+	Caller bool
 }
 
 var _ ToolboxError = &ClientServerError{}
@@ -85,6 +89,13 @@ func NewClientServerError(msg string, code int, cause error) *ClientServerError
 	return &ClientServerError{Msg: msg, Code: code, Cause: cause}
 }
 
+// NewCallerError returns a 401/403 error caused by the credentials of the
+// caller. Unlike other 401/403 errors, it is returned to the caller even if
+// the tool doesn't use client authorization.
+func NewCallerError(msg string, code int, cause error) *ClientServerError {
+	return &ClientServerError{Msg: msg, Code: code, Cause: cause, Caller: true}
+}
+
 // ProcessGcpError catches auth related errors in GCP requests results and return 401/403 error codes
 // Returns AgentError for all other errors
 func ProcessGcpError(err error) ToolboxError {
@@ -115,6 +126,13 @@ func ProcessGeneralError(err error) ToolboxError {
 		return nil
 	}
 
+	// Keep errors that were already categorized, such as a failed credential
+	// exchange
+	var tbErr ToolboxError
+	if errors.As(err, &tbErr) {
+		return tbErr
+	}
+
 	errStr := err.Error()
 
 	// Check for Unauthorized
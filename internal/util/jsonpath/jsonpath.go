@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpath selects values of tool results with simple JSONPath-style
+// expressions, such as `$.items[0].id`.
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+)
+
+// segment is a step of a path: the key of an object or the index of a list.
+type segment struct {
+	key   string
+	index int
+	isKey bool
+}
+
+// Path is a JSONPath-style expression such as `$.steps.customer[0].id`.
+type Path []segment
+
+// Parse parses a path. It starts with `$`, followed by `.key`, `["key"]` or
+// `[index]` segments.
+func Parse(s string) (Path, error) {
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("path %q must start with \"$\"", s)
+	}
+	var p Path
+	rest := s[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("path %q has an empty key", s)
+			}
+			p = append(p, segment{key: key, isKey: true})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unclosed \"[\"", s)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			if unquoted, err := strconv.Unquote(inner); err == nil {
+				p = append(p, segment{key: unquoted, isKey: true})
+			} else if strings.HasPrefix(inner, "'") && strings.HasSuffix(inner, "'") && len(inner) > 1 {
+				p = append(p, segment{key: inner[1 : len(inner)-1], isKey: true})
+			} else {
+				i, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("path %q has an invalid index %q", s, inner)
+				}
+				p = append(p, segment{index: i})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %q is invalid at %q", s, rest)
+		}
+	}
+	return p, nil
+}
+
+// Get returns the value selected by the path, or nil if it doesn't exist.
+// Negative indexes count from the end of the list.
+func (p Path) Get(v any) any {
+	for _, seg := range p {
+		switch cur := v.(type) {
+		case map[string]any:
+			if !seg.isKey {
+				return nil
+			}
+			v = cur[seg.key]
+		case orderedmap.Row:
+			if !seg.isKey {
+				return nil
+			}
+			v = nil
+			for _, col := range cur.Columns {
+				if col.Name == seg.key {
+					v = col.Value
+					break
+				}
+			}
+		case []any:
+			if seg.isKey {
+				return nil
+			}
+			i := seg.index
+			if i < 0 {
+				i += len(cur)
+			}
+			if i < 0 || i >= len(cur) {
+				return nil
+			}
+			v = cur[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// Normalize converts v to the types of decoded JSON, with json.Number
+// numbers, so that the results of every tool can be selected by paths.
+// Strings holding a JSON object or list are decoded.
+func Normalize(v any) (any, error) {
+	return normalize(v, false)
+}
+
+// NormalizeOrdered is like Normalize, but decodes objects as orderedmap.Row
+// to keep the order of their keys.
+func NormalizeOrdered(v any) (any, error) {
+	return normalize(v, true)
+}
+
+func normalize(v any, ordered bool) (any, error) {
+	if s, ok := v.(string); ok {
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			if out, err := decode([]byte(trimmed), ordered); err == nil {
+				return out, nil
+			}
+		}
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(b, ordered)
+}
+
+func decode(b []byte, ordered bool) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	v, err := decodeValue(d, ordered)
+	if err != nil {
+		return nil, err
+	}
+	if d.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func decodeValue(d *json.Decoder, ordered bool) (any, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		var row orderedmap.Row
+		m := make(map[string]any)
+		for d.More() {
+			keyTok, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			v, err := decodeValue(d, ordered)
+			if err != nil {
+				return nil, err
+			}
+			if ordered {
+				row.Add(key, v)
+			} else {
+				m[key] = v
+			}
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+		if ordered {
+			return row, nil
+		}
+		return m, nil
+	case json.Delim('['):
+		list := make([]any, 0)
+		for d.More() {
+			v, err := decodeValue(d, ordered)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+		return list, nil
+	default:
+		return tok, nil
+	}
+}
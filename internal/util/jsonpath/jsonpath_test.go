@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util/jsonpath"
+	"github.com/googleapis/genai-toolbox/internal/util/orderedmap"
+)
+
+func TestGet(t *testing.T) {
+	var row orderedmap.Row
+	row.Add("id", json.Number("9"))
+	data := map[string]any{
+		"params": map[string]any{"email": "ada@example.com"},
+		"customer": []any{
+			map[string]any{"id": json.Number("7"), "first name": "Ada"},
+			map[string]any{"id": json.Number("8"), "first name": "Grace"},
+		},
+		"row": row,
+	}
+	tcs := []struct {
+		path string
+		want any
+	}{
+		{path: "$", want: data},
+		{path: "$.params.email", want: "ada@example.com"},
+		{path: "$.customer[0].id", want: json.Number("7")},
+		{path: "$.customer[-1].id", want: json.Number("8")},
+		{path: `$.customer[1]["first name"]`, want: "Grace"},
+		{path: "$.customer[1]['first name']", want: "Grace"},
+		{path: "$.row.id", want: json.Number("9")},
+		{path: "$.row.missing", want: nil},
+		{path: "$.customer[2].id", want: nil},
+		{path: "$.missing.id", want: nil},
+		{path: "$.params[0]", want: nil},
+		{path: "$.customer.id", want: nil},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := jsonpath.Parse(tc.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, p.Get(data)); diff != "" {
+				t.Fatalf("incorrect value: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{"params.email", "$.", "$..a", "$[0", "$[a]", "$a"} {
+		if _, err := jsonpath.Parse(s); err == nil {
+			t.Errorf("Parse(%q): expected an error", s)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	var row orderedmap.Row
+	row.Add("b", 1)
+	row.Add("a", []int{2})
+
+	tcs := []struct {
+		desc        string
+		in          any
+		want        any
+		wantOrdered any
+	}{
+		{
+			desc:        "row",
+			in:          []any{row},
+			want:        []any{map[string]any{"b": json.Number("1"), "a": []any{json.Number("2")}}},
+			wantOrdered: []any{orderedmap.Row{Columns: []orderedmap.Column{{Name: "b", Value: json.Number("1")}, {Name: "a", Value: []any{json.Number("2")}}}}},
+		},
+		{
+			desc:        "JSON string",
+			in:          `{"b": null, "a": true}`,
+			want:        map[string]any{"b": nil, "a": true},
+			wantOrdered: orderedmap.Row{Columns: []orderedmap.Column{{Name: "b", Value: nil}, {Name: "a", Value: true}}},
+		},
+		{
+			desc:        "text",
+			in:          "{not json",
+			want:        "{not json",
+			wantOrdered: "{not json",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := jsonpath.Normalize(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect value: diff %v", diff)
+			}
+			got, err = jsonpath.NormalizeOrdered(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantOrdered, got); diff != "" {
+				t.Fatalf("incorrect ordered value: diff %v", diff)
+			}
+		})
+	}
+}
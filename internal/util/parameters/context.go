@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// value transforms applied to string parameter values
+const (
+	transformLower = "lower"
+	transformUpper = "upper"
+	transformTrim  = "trim"
+)
+
+// formats for ContextDefault.Now
+const (
+	nowDate     = "date"
+	nowDateTime = "datetime"
+	nowUnix     = "unix"
+)
+
+// ContextDefault is a default value computed when the tool is invoked,
+// configured as `default: {now: date}`.
+type ContextDefault struct {
+	// Now is the format of the current time: "date" (2006-01-02), "datetime"
+	// (RFC 3339) or "unix" (seconds since epoch).
+	Now string `yaml:"now" validate:"required,oneof=date datetime unix"`
+}
+
+// Value returns the default value for the current invocation.
+func (d *ContextDefault) Value() any {
+	t := time.Now().UTC()
+	switch d.Now {
+	case nowDate:
+		return t.Format(time.DateOnly)
+	case nowDateTime:
+		return t.Format(time.RFC3339)
+	case nowUnix:
+		return t.Unix()
+	}
+	return nil
+}
+
+// GetFromHeader returns the request header the Parameter is read from.
+func (p *CommonParameter) GetFromHeader() string {
+	return p.FromHeader
+}
+
+// GetDefaultFrom returns the default computed at invocation time, if any.
+func (p *CommonParameter) GetDefaultFrom() *ContextDefault {
+	return p.DefaultFrom
+}
+
+// ApplyTransform applies the configured transform and lookup table to the
+// value, before it is parsed.
+func (p *CommonParameter) ApplyTransform(v any) (any, error) {
+	if p.Transform != "" {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("transform %q can only be applied to string values", p.Transform)
+		}
+		switch p.Transform {
+		case transformLower:
+			v = strings.ToLower(s)
+		case transformUpper:
+			v = strings.ToUpper(s)
+		case transformTrim:
+			v = strings.TrimSpace(s)
+		default:
+			return nil, fmt.Errorf("%q is not a valid transform", p.Transform)
+		}
+	}
+	if p.Lookup != nil {
+		key := fmt.Sprintf("%v", v)
+		mapped, ok := p.Lookup[key]
+		if !ok {
+			return nil, fmt.Errorf("no lookup entry for %q", key)
+		}
+		v = mapped
+	}
+	return v, nil
+}
+
+// defaultValue returns the default value of the Parameter, preferring the
+// default computed from the invocation context.
+func defaultValue(p Parameter) any {
+	if d := p.GetDefaultFrom(); d != nil {
+		return d.Value()
+	}
+	return p.GetDefault()
+}
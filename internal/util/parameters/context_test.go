@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+func parseParameters(t *testing.T, in string) parameters.Parameters {
+	t.Helper()
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var ps parameters.Parameters
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &ps); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	return ps
+}
+
+func TestParseParamsWithContext(t *testing.T) {
+	ps := parseParameters(t, `
+		- name: email
+			type: string
+			description: the user email
+			transform: lower
+			authServices:
+				- name: my-auth
+					field: email
+		- name: tenant
+			type: string
+			description: the tenant
+			fromHeader: X-Tenant
+		- name: region
+			type: string
+			description: the region code
+			transform: trim
+			lookup:
+				us: us-central1
+				eu: europe-west1
+		- name: day
+			type: string
+			description: the day
+			default:
+				now: date
+		- name: since
+			type: integer
+			description: unix timestamp
+			default:
+				now: unix
+	`)
+
+	claims := map[string]map[string]any{"my-auth": {"email": "Jane.Doe@Example.com"}}
+	headers := http.Header{}
+	headers.Set("X-Tenant", "acme")
+	got, err := parameters.ParseParamsWithHeaders(ps, map[string]any{"region": " eu "}, claims, headers)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	m := got.AsMap()
+	if m["email"] != "jane.doe@example.com" {
+		t.Errorf("email = %v, want lowercased claim", m["email"])
+	}
+	if m["tenant"] != "acme" {
+		t.Errorf("tenant = %v, want header value", m["tenant"])
+	}
+	if m["region"] != "europe-west1" {
+		t.Errorf("region = %v, want looked up value", m["region"])
+	}
+	if _, err := time.Parse(time.DateOnly, m["day"].(string)); err != nil {
+		t.Errorf("day = %v, want a date: %s", m["day"], err)
+	}
+	if since, ok := m["since"].(int); !ok || since <= 0 {
+		t.Errorf("since = %v, want a unix timestamp", m["since"])
+	}
+
+	// client provided values override context defaults
+	got, err = parameters.ParseParamsWithHeaders(ps, map[string]any{"region": "us", "day": "2024-01-01"}, claims, headers)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if day := got.AsMap()["day"]; day != "2024-01-01" {
+		t.Errorf("day = %v, want client value", day)
+	}
+
+	tcs := []struct {
+		desc    string
+		data    map[string]any
+		headers http.Header
+		err     string
+	}{
+		{
+			desc:    "missing header",
+			data:    map[string]any{"region": "us"},
+			headers: http.Header{},
+			err:     `missing header "X-Tenant" for parameter "tenant"`,
+		},
+		{
+			desc:    "missing lookup entry",
+			data:    map[string]any{"region": "apac"},
+			headers: headers,
+			err:     `unable to transform value for "region"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := parameters.ParseParamsWithHeaders(ps, tc.data, claims, tc.headers)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestContextParametersManifest(t *testing.T) {
+	ps := parseParameters(t, `
+		- name: tenant
+			type: string
+			description: the tenant
+			fromHeader: X-Tenant
+		- name: day
+			type: string
+			description: the day
+			default:
+				now: date
+	`)
+
+	schema, _ := ps.McpManifest()
+	if _, ok := schema.Properties["tenant"]; ok {
+		t.Errorf("parameter from header should be hidden from the MCP schema")
+	}
+	if _, ok := schema.Properties["day"]; !ok {
+		t.Errorf("parameter with a context default should be in the MCP schema")
+	}
+	if diff := cmp.Diff([]string{}, schema.Required); diff != "" {
+		t.Errorf("parameters with context defaults should not be required: diff %v", diff)
+	}
+
+	manifest := ps.Manifest()
+	if len(manifest) != 1 || manifest[0].Name != "day" || manifest[0].Required {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+}
+
+func TestInvalidTransform(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+		- name: email
+			type: string
+			description: the user email
+			transform: reverse
+	`
+	var ps parameters.Parameters
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &ps); err == nil {
+		t.Fatalf("expected error for invalid transform")
+	}
+}
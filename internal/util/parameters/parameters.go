@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
 	"regexp"
@@ -129,6 +130,12 @@ func CheckParamRequired(required bool, defaultV any) bool {
 
 // ParseParams is a helper function for parsing Parameters from an arbitraryJSON object.
 func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[string]any) (ParamValues, error) {
+	return ParseParamsWithHeaders(ps, data, claimsMap, nil)
+}
+
+// ParseParamsWithHeaders is like ParseParams, but also resolves parameters
+// configured with `fromHeader` from the request headers.
+func ParseParamsWithHeaders(ps Parameters, data map[string]any, claimsMap map[string]map[string]any, headers http.Header) (ParamValues, error) {
 	params := make([]ParamValue, 0, len(ps))
 	for _, p := range ps {
 		var v, newV any
@@ -140,12 +147,22 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		if sourceParamName != "" {
 			v = data[sourceParamName]
 
+		} else if header := p.GetFromHeader(); header != "" {
+			// parse parameter from request header
+			if hv := headers.Get(header); hv != "" {
+				v = hv
+			} else {
+				v = defaultValue(p)
+				if CheckParamRequired(p.GetRequired(), v) {
+					return nil, util.NewClientServerError(fmt.Sprintf("missing header %q for parameter %q", header, name), http.StatusBadRequest, nil)
+				}
+			}
 		} else if len(paramAuthServices) == 0 {
 			// parse non auth-required parameter
 			var ok bool
 			v, ok = data[name]
 			if !ok || v == nil {
-				v = p.GetDefault()
+				v = defaultValue(p)
 				// if the parameter is required and no value given, throw an error
 				if CheckParamRequired(p.GetRequired(), v) {
 					return nil, util.NewAgentError(fmt.Sprintf("parameter %q is required", name), nil)
@@ -159,6 +176,10 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 			}
 		}
 		if v != nil {
+			v, err = p.ApplyTransform(v)
+			if err != nil {
+				return nil, util.NewAgentError(fmt.Sprintf("unable to transform value for %q", name), err)
+			}
 			newV, err = p.Parse(v)
 			if err != nil {
 				return nil, util.NewAgentError(fmt.Sprintf("unable to parse value for %q", name), err)
@@ -325,6 +346,9 @@ type Parameter interface {
 	GetAuthServices() []ParamAuthService
 	GetEmbeddedBy() string
 	GetValueFromParam() string
+	GetFromHeader() string
+	GetDefaultFrom() *ContextDefault
+	ApplyTransform(any) (any, error)
 	Parse(any) (any, error)
 	Manifest() ParameterManifest
 	McpManifest() (ParameterMcpManifest, []string)
@@ -335,6 +359,18 @@ type McpToolsSchema struct {
 	Type       string                          `json:"type"`
 	Properties map[string]ParameterMcpManifest `json:"properties"`
 	Required   []string                        `json:"required"`
+	// The fields below express constraints across parameters.
+	OneOf             []McpSchemaRule     `json:"oneOf,omitempty"`
+	AnyOf             []McpSchemaRule     `json:"anyOf,omitempty"`
+	AllOf             []McpSchemaRule     `json:"allOf,omitempty"`
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+}
+
+// McpSchemaRule is a JSON Schema subschema of McpToolsSchema.
+type McpSchemaRule struct {
+	Required []string        `json:"required,omitempty"`
+	OneOf    []McpSchemaRule `json:"oneOf,omitempty"`
+	AnyOf    []McpSchemaRule `json:"anyOf,omitempty"`
 }
 
 // Parameters is a type used to allow unmarshal a list of parameters
@@ -375,6 +411,13 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 
 // ParseParameter parses a raw map into a Parameter object based on its "type" field.
 func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Parameter, error) {
+	// a default given as a map is computed at invocation time, except for
+	// map parameters where it is the literal default value
+	if d, ok := p["default"].(map[string]any); ok && paramType != TypeMap {
+		p = maps.Clone(p)
+		delete(p, "default")
+		p["defaultFrom"] = d
+	}
 	dec, err := util.NewStrictDecoder(p)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %w", err)
@@ -438,7 +481,8 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 func (ps Parameters) Manifest() []ParameterManifest {
 	rtn := make([]ParameterManifest, 0, len(ps))
 	for _, p := range ps {
-		if p.GetValueFromParam() != "" {
+		// parameters resolved by the server are not provided by clients
+		if p.GetValueFromParam() != "" || p.GetFromHeader() != "" {
 			continue
 		}
 		rtn = append(rtn, p.Manifest())
@@ -452,8 +496,9 @@ func (ps Parameters) McpManifest() (McpToolsSchema, map[string][]string) {
 	authParam := make(map[string][]string)
 
 	for _, p := range ps {
-		// If the parameter is sourced from another param, skip it in the MCP manifest
-		if p.GetValueFromParam() != "" {
+		// If the parameter is sourced from another param or a request header,
+		// skip it in the MCP manifest
+		if p.GetValueFromParam() != "" || p.GetFromHeader() != "" {
 			continue
 		}
 
@@ -513,6 +558,11 @@ type CommonParameter struct {
 	AuthServices   []ParamAuthService `yaml:"authServices"`
 	EmbeddedBy     string             `yaml:"embeddedBy"`
 	ValueFromParam string             `yaml:"valueFromParam"`
+	FromHeader     string             `yaml:"fromHeader"`
+	Transform      string             `yaml:"transform" validate:"omitempty,oneof=lower upper trim"`
+	Lookup         map[string]any     `yaml:"lookup"`
+	// DefaultFrom is set from a `default` given as a map, e.g. `default: {now: date}`
+	DefaultFrom *ContextDefault `yaml:"defaultFrom"`
 }
 
 // GetName returns the name specified for the Parameter.
@@ -527,6 +577,10 @@ func (p *CommonParameter) GetType() string {
 
 // GetRequired returns the type specified for the Parameter.
 func (p *CommonParameter) GetRequired() bool {
+	// parameters with a default computed at invocation time are optional
+	if p.DefaultFrom != nil {
+		return false
+	}
 	// parameters are defaulted to required
 	if p.Required == nil {
 		return true